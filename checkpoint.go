@@ -0,0 +1,42 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+// Checkpoint is a position of a text that it can be restored to, it
+// is only valid for the text that created it. Length is the number
+// of runes that were left at that position. Forms read after it no
+// longer count toward the Forms limit once it is restored.
+type Checkpoint interface {
+	Location() Location
+	Length() int
+}
+
+type checkpoint struct {
+	location Location
+	content  string
+	length   int
+	forms    int
+}
+
+func (c checkpoint) Location() Location {
+	return c.location
+}
+
+func (c checkpoint) Length() int {
+	return c.length
+}
+
+func (s *script) Checkpoint() Checkpoint {
+	return checkpoint{s.Location(), s.content, s.length, s.forms}
+}
+
+func (s *script) Restore(c Checkpoint) {
+	saved := c.(checkpoint)
+	s.content = saved.content
+	s.length = saved.length
+	s.forms = saved.forms
+	s.line = saved.location.Line()
+	s.column = saved.location.Column()
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package main
+
+import(
+	"encoding/json"
+	"io"
+	"os"
+	"github.com/begopher/peruse"
+)
+
+type jsonFile struct {
+	File   string      `json:"file"`
+	Tokens []jsonToken `json:"tokens,omitempty"`
+	Forms  []jsonForm  `json:"forms,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+type jsonLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func location(l peruse.Location) jsonLocation {
+	return jsonLocation{l.Line(), l.Column()}
+}
+
+func encode(w io.Writer, files []jsonFile) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(files)
+}
+
+func open(path string) (peruse.Text, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return peruse.Script(path, string(content)), nil
+}
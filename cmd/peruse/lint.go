@@ -0,0 +1,123 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package main
+
+import(
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"github.com/begopher/peruse"
+	"github.com/begopher/peruse/lint"
+)
+
+func lintFiles(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	enable := flags.String("enable", "", "comma separated rules to run instead of all of them")
+	disable := flags.String("disable", "", "comma separated rules not to run")
+	list := flags.Bool("rules", false, "list the rules and exit")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *list {
+		for _, rule := range lint.Rules() {
+			fmt.Fprintf(stdout, "%s\t%s\n", rule.Name(), rule.Doc())
+		}
+		return 0
+	}
+	rules, err := selectRules(*enable, *disable)
+	if err != nil {
+		fmt.Fprintf(stderr, "peruse: %s\n", err)
+		return 2
+	}
+	status := 0
+	for _, path := range flags.Args() {
+		text, err := open(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "peruse: %s\n", err)
+			status = 1
+			continue
+		}
+		run := rules
+		tree, err := peruse.Parse(text.Origin(), text.Remain())
+		if err != nil {
+			// the rules that only read the content still check it
+			fmt.Fprintf(stderr, "peruse: %s\n", err)
+			status = 1
+			tree, run = lint.Source(text.Origin(), text.Remain()), sourceRules(rules)
+		}
+		diagnostics, err := lint.Run(tree, run...)
+		if err != nil {
+			fmt.Fprintf(stderr, "peruse: %s\n", err)
+			return 1
+		}
+		for _, diagnostic := range diagnostics {
+			fmt.Fprintln(stdout, diagnostic)
+			status = 1
+		}
+	}
+	return status
+}
+
+// sourceRules returns the rules of rules that are SourceRules.
+func sourceRules(rules []lint.Rule) []lint.Rule {
+	source := map[string]bool{}
+	for _, rule := range lint.SourceRules() {
+		source[rule.Name()] = true
+	}
+	result := []lint.Rule{}
+	for _, rule := range rules {
+		if source[rule.Name()] {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// selectRules returns the rules named by enable, or all of them, less
+// the ones named by disable.
+func selectRules(enable, disable string) ([]lint.Rule, error) {
+	known := map[string]lint.Rule{}
+	for _, rule := range lint.Rules() {
+		known[rule.Name()] = rule
+	}
+	names := func(list string) ([]string, error) {
+		result := []string{}
+		for _, name := range strings.Split(list, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			if _, ok := known[name]; !ok {
+				return nil, fmt.Errorf("unknown rule %q", name)
+			}
+			result = append(result, name)
+		}
+		return result, nil
+	}
+	enabled, err := names(enable)
+	if err != nil {
+		return nil, err
+	}
+	disabled, err := names(disable)
+	if err != nil {
+		return nil, err
+	}
+	off := map[string]bool{}
+	for _, name := range disabled {
+		off[name] = true
+	}
+	rules := []lint.Rule{}
+	for _, rule := range lint.Rules() {
+		on := len(enabled) == 0
+		for _, name := range enabled {
+			on = on || name == rule.Name()
+		}
+		if on && !off[rule.Name()] {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+// Command peruse shows how scripts are split by the peruse package.
+//
+// Usage:
+//
+//	peruse tokens [-json] file...
+//	peruse tree [-json] file...
+//	peruse lint [-enable rule,...] [-disable rule,...] [-rules] file...
+//
+// The tokens command prints every lexeme with its kind and location,
+// the tree command prints the forms read from each file and the lint
+// command reports the problems found by the rules of package lint.
+package main
+
+import(
+	"fmt"
+	"io"
+	"os"
+)
+
+type command func(args []string, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"tokens": tokens,
+	"tree":   tree,
+	"lint":   lintFiles,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "peruse: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	return command(args[1:], stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: peruse tokens [-json] file...")
+	fmt.Fprintln(w, "       peruse tree [-json] file...")
+	fmt.Fprintln(w, "       peruse lint [-enable rule,...] [-disable rule,...] [-rules] file...")
+}
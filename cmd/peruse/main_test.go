@@ -0,0 +1,153 @@
+package main
+
+import(
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func script(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "main.lisp")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTokens(t *testing.T) {
+	path := script(t, "(f :k\n \"s\" \"a\nb\")")
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"tokens", path}, &stdout, &stderr); status != 0 {
+		t.Fatalf("tokens returns status (%d): %s", status, stderr.String())
+	}
+	expected := strings.Join([]string{
+		path + ":1:1\topen\t\"(\"",
+		path + ":1:2\tsymbol\t\"f\"",
+		path + ":1:4\tkeyword\t\":k\"",
+		path + ":2:2\tstring\t\"\\\"s\\\"\"",
+		path + ":2:6\tstring\t\"\\\"a\\nb\\\"\"",
+		path + ":3:2\tclose\t\")\"",
+	}, "\n") + "\n"
+	if got := stdout.String(); got != expected {
+		t.Errorf("tokens output: got\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestTree(t *testing.T) {
+	path := script(t, "(f (g 1))")
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"tree", path}, &stdout, &stderr); status != 0 {
+		t.Fatalf("tree returns status (%d): %s", status, stderr.String())
+	}
+	expected := strings.Join([]string{
+		path + ":1:1\tlist",
+		"  " + path + ":1:2\tsymbol f",
+		"  " + path + ":1:4\tlist",
+		"    " + path + ":1:5\tsymbol g",
+		"    " + path + ":1:7\tinteger 1",
+	}, "\n") + "\n"
+	if got := stdout.String(); got != expected {
+		t.Errorf("tree output: got\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	path := script(t, "(f 1)")
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"tree", "-json", path}, &stdout, &stderr); status != 0 {
+		t.Fatalf("tree -json returns status (%d): %s", status, stderr.String())
+	}
+	var files []jsonFile
+	if err := json.Unmarshal(stdout.Bytes(), &files); err != nil {
+		t.Fatalf("tree -json output is not JSON: %v", err)
+	}
+	if len(files) != 1 || len(files[0].Forms) != 1 || len(files[0].Forms[0].Forms) != 2 {
+		t.Fatalf("tree -json returns unexpected forms: %s", stdout.String())
+	}
+	if got, expected := files[0].Forms[0].End, (jsonLocation{1, 6}); got != expected {
+		t.Errorf("tree -json end: got(%v) expected (%v)", got, expected)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	path := script(t, "(f \"s")
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"tokens", "-json", path}, &stdout, &stderr); status != 1 {
+		t.Errorf("tokens returns status (%d) expected (1)", status)
+	}
+	var files []jsonFile
+	if err := json.Unmarshal(stdout.Bytes(), &files); err != nil {
+		t.Fatalf("tokens -json output is not JSON: %v", err)
+	}
+	if got, expected := files[0].Error, path+":1:4: unterminated string"; got != expected {
+		t.Errorf("tokens -json error: got(%s) expected (%s)", got, expected)
+	}
+	if status := run([]string{"unknown"}, &stdout, &stderr); status != 2 {
+		t.Errorf("unknown command returns status (%d) expected (2)", status)
+	}
+}
+
+func TestLint(t *testing.T) {
+	path := script(t, "(define x 1)\n(define x 2)\n(lambda ())  \n")
+	table := []struct {
+		args []string
+		status int
+		expected []string
+	}{
+		{
+			args: []string{"lint", path},
+			status: 1,
+			expected: []string{
+				path + ":2:9: x is already defined at " + path + ":1:9 (duplicate-define)",
+				path + ":3:1: lambda has an empty body (empty-body)",
+				path + ":3:12: trailing space (trailing-space)",
+			},
+		},
+		{
+			args: []string{"lint", "-enable", "empty-body,trailing-space", "-disable", "trailing-space", path},
+			status: 1,
+			expected: []string{path + ":3:1: lambda has an empty body (empty-body)"},
+		},
+		{
+			args: []string{"lint", "-disable", "duplicate-define,empty-body,trailing-space", path},
+			status: 0,
+			expected: []string{},
+		},
+		{
+			args: []string{"lint", "-enable", "missing", path},
+			status: 2,
+			expected: []string{},
+		},
+	}
+	for _, row := range table {
+		var stdout, stderr bytes.Buffer
+		if status := run(row.args, &stdout, &stderr); status != row.status {
+			t.Errorf("%v: got status (%d) expected (%d): %s", row.args, status, row.status, stderr.String())
+		}
+		expected := strings.Join(row.expected, "\n")
+		if len(row.expected) != 0 {
+			expected += "\n"
+		}
+		if got := stdout.String(); got != expected {
+			t.Errorf("%v: got\n%s\nexpected\n%s", row.args, got, expected)
+		}
+	}
+}
+
+func TestLintUnparsed(t *testing.T) {
+	path := script(t, "(define admin \"\u202E\")\n(define x")
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"lint", path}, &stdout, &stderr); status != 1 {
+		t.Errorf("got status (%d) expected (1)", status)
+	}
+	expected := path + ":1:16: bidirectional control character U+202E (RIGHT-TO-LEFT OVERRIDE) can make the code read differently from how it runs (bidi)\n"
+	if got := stdout.String(); got != expected {
+		t.Errorf("got\n%s\nexpected\n%s", got, expected)
+	}
+	if got, expected := stderr.String(), "peruse: "+path+":2:1: unclosed '('\n"; got != expected {
+		t.Errorf("stderr: got(%s) expected (%s)", got, expected)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package main
+
+import(
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"github.com/begopher/peruse"
+)
+
+type jsonToken struct {
+	Kind  string       `json:"kind"`
+	Text  string       `json:"text"`
+	Start jsonLocation `json:"start"`
+	End   jsonLocation `json:"end"`
+}
+
+func tokens(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("tokens", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	asJSON := flags.Bool("json", false, "print tokens as JSON")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	status := 0
+	files := []jsonFile{}
+	for _, path := range flags.Args() {
+		file := jsonFile{File: path, Tokens: []jsonToken{}}
+		err := each(path, func(token peruse.Token) {
+			if !*asJSON {
+				fmt.Fprintf(stdout, "%s\t%s\t%q\n", token.Span().Start(), token.Kind(), token.Text())
+				return
+			}
+			file.Tokens = append(file.Tokens, jsonToken{
+				Kind:  token.Kind().String(),
+				Text:  token.Text(),
+				Start: location(token.Span().Start()),
+				End:   location(token.Span().End()),
+			})
+		})
+		if err != nil {
+			status = 1
+			file.Error = err.Error()
+			if !*asJSON {
+				fmt.Fprintf(stderr, "peruse: %s\n", err)
+			}
+		}
+		files = append(files, file)
+	}
+	if *asJSON {
+		if err := encode(stdout, files); err != nil {
+			fmt.Fprintf(stderr, "peruse: %s\n", err)
+			return 1
+		}
+	}
+	return status
+}
+
+// each calls f for every token of the file at path.
+func each(path string, f func(peruse.Token)) error {
+	text, err := open(path)
+	if err != nil {
+		return err
+	}
+	for {
+		token, err := peruse.Lex(text)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		f(token)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package main
+
+import(
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"github.com/begopher/peruse"
+)
+
+type jsonForm struct {
+	Kind  string       `json:"kind"`
+	Value string       `json:"value,omitempty"`
+	Start jsonLocation `json:"start"`
+	End   jsonLocation `json:"end"`
+	Forms []jsonForm   `json:"forms,omitempty"`
+}
+
+func tree(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("tree", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	asJSON := flags.Bool("json", false, "print forms as JSON")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	status := 0
+	files := []jsonFile{}
+	for _, path := range flags.Args() {
+		file := jsonFile{File: path, Forms: []jsonForm{}}
+		forms, err := parse(path)
+		if err != nil {
+			status = 1
+			file.Error = err.Error()
+			if !*asJSON {
+				fmt.Fprintf(stderr, "peruse: %s\n", err)
+			}
+		}
+		for _, form := range forms {
+			if *asJSON {
+				file.Forms = append(file.Forms, convert(form))
+				continue
+			}
+			dump(stdout, form, 0)
+		}
+		files = append(files, file)
+	}
+	if *asJSON {
+		if err := encode(stdout, files); err != nil {
+			fmt.Fprintf(stderr, "peruse: %s\n", err)
+			return 1
+		}
+	}
+	return status
+}
+
+func parse(path string) ([]peruse.Form, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := peruse.Parse(path, string(content))
+	if err != nil {
+		return nil, err
+	}
+	for _, form := range parsed.Forms() {
+		if peruse.Cyclic(form) {
+			return nil, peruse.NewDiagnostic(form.Span().Start(), "form contains itself")
+		}
+	}
+	return parsed.Forms(), nil
+}
+
+func dump(w io.Writer, form peruse.Form, depth int) {
+	indent := strings.Repeat("  ", depth)
+	if form.Kind() != peruse.List {
+		fmt.Fprintf(w, "%s%s\t%s %s\n", indent, form.Span().Start(), form.Kind(), source(form))
+		return
+	}
+	fmt.Fprintf(w, "%s%s\t%s\n", indent, form.Span().Start(), form.Kind())
+	for _, child := range form.Forms() {
+		dump(w, child, depth+1)
+	}
+}
+
+// source returns an atom as it is written in the script.
+func source(form peruse.Form) string {
+	if form.Kind() == peruse.String || form.Kind() == peruse.RawString {
+		return form.String()
+	}
+	return form.Value()
+}
+
+func convert(form peruse.Form) jsonForm {
+	result := jsonForm{
+		Kind:  form.Kind().String(),
+		Value: form.Value(),
+		Start: location(form.Span().Start()),
+		End:   location(form.Span().End()),
+	}
+	for _, child := range form.Forms() {
+		result.Forms = append(result.Forms, convert(child))
+	}
+	return result
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+// Package combinator builds parsers out of the methods of peruse.Text.
+//
+// A parser returns a value or an error located where parsing failed.
+// The primitives skip the spaces and comments in front of what they
+// eat and put them back when they fail without eating. Alt restores the
+// text before trying each of its alternatives, Many, Many1, Optional
+// and SepBy stop at a failure that ate nothing and return the others,
+// so an error deep inside a form is not hidden by a retry.
+package combinator
+
+import(
+	"fmt"
+	"sync"
+	"github.com/begopher/peruse"
+)
+
+type Parser[T any] func(text peruse.Text) (T, error)
+
+// Seq runs parsers one after another and returns their values.
+func Seq[T any](parsers ...Parser[T]) Parser[[]T] {
+	return func(text peruse.Text) ([]T, error) {
+		values := []T{}
+		for _, parser := range parsers {
+			value, err := parser(text)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+}
+
+// Alt returns the value of the first parser that succeeds, when all
+// of them fail the error of the one that got furthest is returned.
+func Alt[T any](parsers ...Parser[T]) Parser[T] {
+	return func(text peruse.Text) (T, error) {
+		var zero T
+		var failure error
+		furthest := 0
+		checkpoint := text.Checkpoint()
+		for _, parser := range parsers {
+			value, err := parser(text)
+			if err == nil {
+				return value, nil
+			}
+			if eaten := checkpoint.Length() - text.Length(); failure == nil || eaten > furthest {
+				failure, furthest = err, eaten
+			}
+			text.Restore(checkpoint)
+		}
+		if failure == nil {
+			return zero, peruse.NewDiagnostic(text.Location(), "no alternative")
+		}
+		return zero, failure
+	}
+}
+
+// Many runs parser as many times as it succeeds.
+func Many[T any](parser Parser[T]) Parser[[]T] {
+	return func(text peruse.Text) ([]T, error) {
+		values := []T{}
+		for {
+			value, ok, err := attempt(text, parser)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return values, nil
+			}
+			values = append(values, value)
+		}
+	}
+}
+
+// Many1 is like Many but parser has to succeed at least once.
+func Many1[T any](parser Parser[T]) Parser[[]T] {
+	many := Many(parser)
+	return func(text peruse.Text) ([]T, error) {
+		first, err := parser(text)
+		if err != nil {
+			return nil, err
+		}
+		rest, err := many(text)
+		if err != nil {
+			return nil, err
+		}
+		return append([]T{first}, rest...), nil
+	}
+}
+
+// Optional returns the zero value of T when parser fails.
+func Optional[T any](parser Parser[T]) Parser[T] {
+	return func(text peruse.Text) (T, error) {
+		value, _, err := attempt(text, parser)
+		return value, err
+	}
+}
+
+// SepBy parses zero or more values separated by sep, a value must
+// follow every separator.
+func SepBy[T, S any](parser Parser[T], sep Parser[S]) Parser[[]T] {
+	return func(text peruse.Text) ([]T, error) {
+		values := []T{}
+		value, ok, err := attempt(text, parser)
+		if err != nil || !ok {
+			return values, err
+		}
+		values = append(values, value)
+		for {
+			_, ok, err := attempt(text, sep)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return values, nil
+			}
+			value, err := parser(text)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+	}
+}
+
+// Between returns the value of parser found between open and close.
+func Between[O, T, C any](open Parser[O], parser Parser[T], close Parser[C]) Parser[T] {
+	return func(text peruse.Text) (T, error) {
+		var zero T
+		if _, err := open(text); err != nil {
+			return zero, err
+		}
+		value, err := parser(text)
+		if err != nil {
+			return zero, err
+		}
+		if _, err := close(text); err != nil {
+			return zero, err
+		}
+		return value, nil
+	}
+}
+
+// Lazy builds its parser on first use, which lets a grammar
+// refer to itself. It is safe to share between goroutines.
+func Lazy[T any](build func() Parser[T]) Parser[T] {
+	var once sync.Once
+	var parser Parser[T]
+	return func(text peruse.Text) (T, error) {
+		once.Do(func() { parser = build() })
+		return parser(text)
+	}
+}
+
+// Map converts the value of parser with convert.
+func Map[T, U any](parser Parser[T], convert func(T) U) Parser[U] {
+	return func(text peruse.Text) (U, error) {
+		var zero U
+		value, err := parser(text)
+		if err != nil {
+			return zero, err
+		}
+		return convert(value), nil
+	}
+}
+
+// attempt runs parser and restores text when it fails without
+// eating anything, that failure is reported as not ok.
+func attempt[T any](text peruse.Text, parser Parser[T]) (T, bool, error) {
+	checkpoint := text.Checkpoint()
+	value, err := parser(text)
+	if err == nil {
+		return value, true, nil
+	}
+	var zero T
+	if text.Length() != checkpoint.Length() {
+		return zero, false, err
+	}
+	text.Restore(checkpoint)
+	return zero, false, nil
+}
+
+func expected(text peruse.Text, what string) error {
+	return peruse.NewDiagnostic(text.Location(), fmt.Sprintf("expected %s", what))
+}
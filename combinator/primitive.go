@@ -0,0 +1,106 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package combinator
+
+import(
+	"fmt"
+	"io"
+	"github.com/begopher/peruse"
+)
+
+// Literal eats prefix as it is.
+func Literal(prefix string) Parser[string] {
+	return token(func(text peruse.Text) (string, error) {
+		if !text.Eat(prefix) {
+			return "", expected(text, fmt.Sprintf("%q", prefix))
+		}
+		return prefix, nil
+	})
+}
+
+// Name eats a symbol that equals name, another symbol is not eaten.
+func Name(name string) Parser[string] {
+	return token(func(text peruse.Text) (string, error) {
+		checkpoint := text.Checkpoint()
+		if symbol := text.EatSymbol(); symbol != name {
+			text.Restore(checkpoint)
+			return "", peruse.NewDiagnostic(checkpoint.Location(), fmt.Sprintf("expected %s", name))
+		}
+		return name, nil
+	})
+}
+
+func Word() Parser[string] {
+	return token(func(text peruse.Text) (string, error) {
+		return text.TryEatWord()
+	})
+}
+
+func Symbol() Parser[string] {
+	return token(func(text peruse.Text) (string, error) {
+		return text.TryEatSymbol()
+	})
+}
+
+func Keyword() Parser[string] {
+	return token(func(text peruse.Text) (string, error) {
+		return text.TryEatKeyword()
+	})
+}
+
+func Integer() Parser[string] {
+	return token(func(text peruse.Text) (string, error) {
+		return text.TryEatInteger()
+	})
+}
+
+func Float() Parser[string] {
+	return token(func(text peruse.Text) (string, error) {
+		return text.TryEatFloat()
+	})
+}
+
+func String() Parser[string] {
+	return token(func(text peruse.Text) (string, error) {
+		return text.TryEatString()
+	})
+}
+
+// Form reads a whole form with peruse.Read.
+func Form() Parser[peruse.Form] {
+	return token(func(text peruse.Text) (peruse.Form, error) {
+		form, err := peruse.Read(text)
+		if err == io.EOF {
+			return nil, expected(text, "a form")
+		}
+		return form, err
+	})
+}
+
+// End succeeds when nothing but spaces and comments is left.
+func End() Parser[string] {
+	return token(func(text peruse.Text) (string, error) {
+		if !text.Empty() {
+			return "", expected(text, "end of text")
+		}
+		return "", nil
+	})
+}
+
+// token skips spaces and comments before eat, the spaces are put
+// back when eat fails without eating anything.
+func token[T any](eat func(peruse.Text) (T, error)) Parser[T] {
+	return func(text peruse.Text) (T, error) {
+		checkpoint := text.Checkpoint()
+		for text.EatSpaces(); text.EatComment() != ""; text.EatSpaces() {
+		}
+		length := text.Length()
+		value, err := eat(text)
+		if err != nil && text.Length() == length {
+			text.Restore(checkpoint)
+		}
+		return value, err
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Features makes the reader read reader conditionals with features as
+// the features that are present:
+//
+//	#+expr form     form is read when expr is true
+//	#-expr form     form is read when expr is false
+//	#?(f1 a f2 b)   a when f1 is true, else b when f2 is true, :default is always true
+//	#?@(f1 (a b))   like #? but a and b are spliced into the enclosing list
+//
+// A feature expression is a feature, with or without a leading colon,
+// or (and expr...), (or expr...) and (not expr). Forms that are not
+// read are still checked for errors.
+func Features(features ...string) Option {
+	return func(s *script) {
+		s.features = map[string]bool{}
+		for _, feature := range features {
+			s.features[feature] = true
+		}
+	}
+}
+
+// Skipped sets report to be called with the span of every region left
+// out by a reader conditional, in the order they are read. Reparse
+// only reports the regions of the forms it reads again.
+func Skipped(report func(Span)) Option {
+	return func(s *script) {
+		s.skipped = report
+	}
+}
+
+// spliced is what a reader conditional reads inside a list, forms are
+// added to the list in its place.
+type spliced struct {
+	form
+	forms []Form
+}
+
+// conditional returns the reader conditional content starts with.
+func conditional(content string) string {
+	for _, marker := range []string{"#?@", "#?", "#+", "#-"} {
+		if strings.HasPrefix(content, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// conditional reads a reader conditional that starts at start with
+// marker, for #+ and #- the form they guard is returned or skipped.
+func (r reader) conditional(marker string, start Location) (Form, error) {
+	text := r.text
+	text.Eat(marker)
+	if marker == "#?" || marker == "#?@" {
+		return r.choose(marker, start)
+	}
+	space(text)
+	expression, err := r.feature(marker)
+	if err != nil {
+		return nil, err
+	}
+	present, err := r.evaluate(expression)
+	if err != nil {
+		return nil, err
+	}
+	if marker == "#-" {
+		present = !present
+	}
+	space(text)
+	if present {
+		return r.guarded(marker)
+	}
+	if err := r.skip(marker, start); err != nil {
+		return nil, err
+	}
+	return r.nothing()
+}
+
+// choose reads the branches of #? or #?@ and returns the form of the
+// first branch whose feature is present.
+func (r reader) choose(marker string, start Location) (Form, error) {
+	text := r.text
+	space(text)
+	if !text.BeginWith("(") {
+		return nil, NewDiagnostic(text.Location(), fmt.Sprintf("expected '(' after %s", marker))
+	}
+	open := text.Location()
+	text.Eat("(")
+	r.depth++
+	if err := nest(text, r.depth, open); err != nil {
+		return nil, err
+	}
+	var chosen Form
+	for {
+		space(text)
+		if text.Empty() {
+			return nil, NewDiagnostic(open, "unclosed '('")
+		}
+		if text.Eat(")") {
+			break
+		}
+		branch := text.Location()
+		expression, err := r.feature(marker)
+		if err != nil {
+			return nil, err
+		}
+		present := expression.Kind() != List && expression.Value() == ":default"
+		if !present {
+			if present, err = r.evaluate(expression); err != nil {
+				return nil, err
+			}
+		}
+		space(text)
+		if text.Empty() || text.BeginWith(")") {
+			return nil, NewDiagnostic(branch, fmt.Sprintf("missing form after feature in %s", marker))
+		}
+		if present && chosen == nil {
+			f, err := r.guarded(marker)
+			if err != nil {
+				return nil, err
+			}
+			chosen = f
+			continue
+		}
+		if err := r.skip(marker, branch); err != nil {
+			return nil, err
+		}
+	}
+	r.depth--
+	if chosen == nil {
+		return r.nothing()
+	}
+	if marker != "#?@" {
+		return chosen, nil
+	}
+	if chosen.Kind() != List {
+		return nil, NewDiagnostic(chosen.Span().Start(), fmt.Sprintf("%s expects a list to splice, found %s", marker, chosen.Kind()))
+	}
+	if r.depth == 0 {
+		return nil, NewDiagnostic(start, fmt.Sprintf("%s splices outside of a list", marker))
+	}
+	return spliced{forms: chosen.Forms()}, nil
+}
+
+// feature reads the feature expression of a conditional.
+func (r reader) feature(marker string) (Form, error) {
+	if r.text.Empty() || r.text.BeginWith(")") {
+		return nil, NewDiagnostic(r.text.Location(), fmt.Sprintf("missing feature expression after %s", marker))
+	}
+	start := r.text.Location()
+	f, err := r.datum()
+	if _, ok := f.(spliced); ok {
+		return nil, NewDiagnostic(start, fmt.Sprintf("missing feature expression after %s", marker))
+	}
+	return f, err
+}
+
+// evaluate tells whether the feature expression f is true.
+func (r reader) evaluate(f Form) (bool, error) {
+	switch f.Kind() {
+	case Symbol, Keyword, Atom:
+		return r.features[strings.TrimPrefix(f.Value(), ":")], nil
+	case List:
+		forms := f.Forms()
+		if len(forms) == 0 {
+			break
+		}
+		operator := forms[0].Value()
+		if operator == "not" && len(forms) != 2 {
+			return false, NewDiagnostic(f.Span().Start(), "not expects 1 feature expression")
+		}
+		if operator != "and" && operator != "or" && operator != "not" {
+			break
+		}
+		for _, operand := range forms[1:] {
+			present, err := r.evaluate(operand)
+			if err != nil {
+				return false, err
+			}
+			switch {
+			case operator == "not":
+				return !present, nil
+			case operator == "and" && !present:
+				return false, nil
+			case operator == "or" && present:
+				return true, nil
+			}
+		}
+		return operator == "and", nil
+	}
+	return false, NewDiagnostic(f.Span().Start(), fmt.Sprintf("invalid feature expression %s", f))
+}
+
+// guarded reads the form a conditional guards.
+func (r reader) guarded(marker string) (Form, error) {
+	if r.text.Empty() || r.text.BeginWith(")") {
+		return nil, NewDiagnostic(r.text.Location(), fmt.Sprintf("missing form after %s", marker))
+	}
+	return r.datum()
+}
+
+// skip reads the form a conditional guards and reports the region from
+// start to its end as skipped.
+func (r reader) skip(marker string, start Location) error {
+	skipping := r
+	skipping.skipping = true
+	if _, err := skipping.guarded(marker); err != nil {
+		return err
+	}
+	if r.skipped != nil && !r.skipping {
+		r.skipped(NewSpan(start, r.text.Location()))
+	}
+	return nil
+}
+
+// nothing is read in place of a conditional whose form is skipped, in
+// a list it adds no form and at the top level the next form is read.
+func (r reader) nothing() (Form, error) {
+	if r.depth > 0 {
+		return spliced{}, nil
+	}
+	space(r.text)
+	if r.text.Empty() {
+		return nil, io.EOF
+	}
+	return r.datum()
+}
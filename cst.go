@@ -0,0 +1,310 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"errors"
+	"strings"
+)
+
+// CST is a concrete syntax tree, it keeps every character of the
+// content it was parsed from: the spaces and comments (trivia) are
+// held by the nodes around them.
+type CST interface {
+	Origin() string
+	Nodes() []Node
+	// Trailing is the trivia after the last node.
+	Trailing() string
+	// String returns the content of the tree, for a parsed tree it is
+	// the parsed content byte for byte.
+	String() string
+	// The edits return a new tree, only the text of the node
+	// (or next to it) changes, the trivia is kept as it is.
+	Replace(node Node, source string) (CST, error)
+	InsertBefore(node Node, source string) (CST, error)
+	InsertAfter(node Node, source string) (CST, error)
+	// Remove deletes node with the blanks before it on its line,
+	// or after it when there are none before.
+	Remove(node Node) (CST, error)
+}
+
+// Node is a list or an atom of a CST. Leading trivia is all the
+// spaces and comments before the node, trailing trivia is what is
+// left of the line the node ends on when no other node follows.
+type Node interface {
+	Kind() Kind
+	Leading() string
+	// Text is the source of an atom as written, for a list it
+	// is the opening parenthesis.
+	Text() string
+	Children() []Node
+	// Closing is the trivia before the parenthesis closing a list.
+	Closing() string
+	Trailing() string
+	// Span covers the node without its trivia.
+	Span() Span
+	// Form is the node as read by Read.
+	Form() Form
+	// String returns the source of the node with its trivia.
+	String() string
+}
+
+// ParseCST parses content into a CST, options are those of Script.
+func ParseCST(origin, content string, options ...Option) (CST, error) {
+	c := &cst{origin: origin, content: content, options: options}
+	p := cstParser{text: Script(origin, content, options...), tree: c}
+	for {
+		leading := p.trivia()
+		if p.text.Empty() {
+			c.trailing = leading
+			return c, nil
+		}
+		n, err := p.node(leading, 0)
+		if err != nil {
+			return nil, err
+		}
+		c.nodes = append(c.nodes, n)
+	}
+}
+
+type cst struct {
+	origin   string
+	content  string
+	options  []Option
+	nodes    []Node
+	trailing string
+}
+
+func (c *cst) Origin() string {
+	return c.origin
+}
+
+func (c *cst) Nodes() []Node {
+	return c.nodes
+}
+
+func (c *cst) Trailing() string {
+	return c.trailing
+}
+
+func (c *cst) String() string {
+	var b strings.Builder
+	for _, n := range c.nodes {
+		b.WriteString(n.String())
+	}
+	b.WriteString(c.trailing)
+	return b.String()
+}
+
+func (c *cst) Replace(target Node, source string) (CST, error) {
+	n, err := c.own(target)
+	if err != nil {
+		return nil, err
+	}
+	return c.splice(n.from, n.to, source)
+}
+
+func (c *cst) InsertBefore(target Node, source string) (CST, error) {
+	n, err := c.own(target)
+	if err != nil {
+		return nil, err
+	}
+	return c.splice(n.from, n.from, source)
+}
+
+func (c *cst) InsertAfter(target Node, source string) (CST, error) {
+	n, err := c.own(target)
+	if err != nil {
+		return nil, err
+	}
+	return c.splice(n.to, n.to, source)
+}
+
+func (c *cst) Remove(target Node) (CST, error) {
+	n, err := c.own(target)
+	if err != nil {
+		return nil, err
+	}
+	from, to := n.from, n.to
+	for from > 0 && blank(c.content[from-1]) {
+		from--
+	}
+	if from == n.from {
+		for to < len(c.content) && blank(c.content[to]) {
+			to++
+		}
+	}
+	return c.splice(from, to, "")
+}
+
+func blank(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
+func (c *cst) own(target Node) (*node, error) {
+	n, ok := target.(*node)
+	if !ok || n.tree != c {
+		return nil, errors.New("node is not of this tree")
+	}
+	return n, nil
+}
+
+// splice parses the content with the bytes from:to replaced by source.
+func (c *cst) splice(from, to int, source string) (CST, error) {
+	return ParseCST(c.origin, c.content[:from]+source+c.content[to:], c.options...)
+}
+
+type node struct {
+	tree     *cst
+	kind     Kind
+	value    string
+	leading  string
+	text     string
+	children []Node
+	closing  string
+	trailing string
+	span     Span
+	// from and to are the byte offsets of the node
+	// in the content, trivia excluded.
+	from int
+	to   int
+}
+
+func (n *node) Kind() Kind {
+	return n.kind
+}
+
+func (n *node) Leading() string {
+	return n.leading
+}
+
+func (n *node) Text() string {
+	return n.text
+}
+
+func (n *node) Children() []Node {
+	return n.children
+}
+
+func (n *node) Closing() string {
+	return n.closing
+}
+
+func (n *node) Trailing() string {
+	return n.trailing
+}
+
+func (n *node) Span() Span {
+	return n.span
+}
+
+func (n *node) Form() Form {
+	if n.kind != List {
+		return NewAtom(n.kind, n.value, n.span)
+	}
+	forms := []Form{}
+	for _, child := range n.children {
+		forms = append(forms, child.Form())
+	}
+	return NewList(forms, n.span)
+}
+
+func (n *node) String() string {
+	var b strings.Builder
+	b.WriteString(n.leading)
+	b.WriteString(n.text)
+	if n.kind == List {
+		for _, child := range n.children {
+			b.WriteString(child.String())
+		}
+		b.WriteString(n.closing)
+		b.WriteByte(')')
+	}
+	b.WriteString(n.trailing)
+	return b.String()
+}
+
+type cstParser struct {
+	text Text
+	tree *cst
+}
+
+func (p cstParser) offset() int {
+	return len(p.tree.content) - len(p.text.Remain())
+}
+
+// trivia eats the spaces and comments the text starts with.
+func (p cstParser) trivia() string {
+	from := p.offset()
+	space(p.text)
+	return p.tree.content[from:p.offset()]
+}
+
+// trailing eats the blanks and the comment before the end of the
+// line, blanks followed by a node are left as its leading trivia.
+func (p cstParser) trailing() string {
+	remain := p.text.Remain()
+	end := 0
+	for end < len(remain) && blank(remain[end]) {
+		end++
+	}
+	switch {
+	case end < len(remain) && remain[end] == ';':
+		if i := strings.IndexByte(remain[end:], '\n'); i >= 0 {
+			end += i
+		} else {
+			end = len(remain)
+		}
+	case end < len(remain) && remain[end] != '\n' && remain[end] != '\r':
+		return ""
+	}
+	p.text.Eat(remain[:end])
+	return remain[:end]
+}
+
+func (p cstParser) node(leading string, depth int) (*node, error) {
+	text := p.text
+	n := &node{tree: p.tree, leading: leading, from: p.offset()}
+	start := text.Location()
+	if err := input(text); err != nil {
+		return nil, err
+	}
+	if err := count(text, start); err != nil {
+		return nil, err
+	}
+	if text.BeginWith("(") {
+		if err := nest(text, depth+1, start); err != nil {
+			return nil, err
+		}
+	}
+	if text.Eat("(") {
+		n.kind, n.text = List, "("
+		for {
+			trivia := p.trivia()
+			if text.Empty() {
+				return nil, NewDiagnostic(start, "unclosed '('")
+			}
+			if text.Eat(")") {
+				n.closing = trivia
+				break
+			}
+			child, err := p.node(trivia, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, child)
+		}
+	} else {
+		var err error
+		if n.kind, n.value, err = lexeme(text); err != nil {
+			return nil, err
+		}
+		n.text = p.tree.content[n.from:p.offset()]
+	}
+	n.to = p.offset()
+	n.span = NewSpan(start, text.Location())
+	n.trailing = p.trailing()
+	return n, nil
+}
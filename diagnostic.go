@@ -0,0 +1,57 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"fmt"
+	"sort"
+)
+
+// Diagnostic is a problem found at a location of a script, it is
+// also the error returned by the reader.
+type Diagnostic interface {
+	Location() Location
+	Message() string
+	Error() string
+}
+
+func NewDiagnostic(location Location, message string) Diagnostic {
+	return diagnostic{location, message}
+}
+
+type diagnostic struct {
+	location Location
+	message  string
+}
+
+func (d diagnostic) Location() Location {
+	return d.location
+}
+
+func (d diagnostic) Message() string {
+	return d.message
+}
+
+func (d diagnostic) Error() string {
+	return fmt.Sprintf("%s: %s", d.location, d.message)
+}
+
+// SortDiagnostics orders diagnostics by origin, line, column and
+// then message.
+func SortDiagnostics(diagnostics []Diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].Location(), diagnostics[j].Location()
+		if a.Origin() != b.Origin() {
+			return a.Origin() < b.Origin()
+		}
+		if a.Line() != b.Line() {
+			return a.Line() < b.Line()
+		}
+		if a.Column() != b.Column() {
+			return a.Column() < b.Column()
+		}
+		return diagnostics[i].Message() < diagnostics[j].Message()
+	})
+}
@@ -0,0 +1,376 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+// Package eval is a small Lisp interpreter meant as a starting
+// point for languages built with peruse.
+//
+// Its core is define, lambda, if, let, quote and begin, the builtins
+// + - * / = < > <= >= and the values true, false and nil. Macros
+// defined by define-syntax are expanded with the macro package.
+// Only false and nil are false. Scopes are lexical.
+//
+// Runtime errors are peruse diagnostics located at the failing call,
+// or at the symbol that is not defined.
+package eval
+
+import(
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"github.com/begopher/peruse"
+	"github.com/begopher/peruse/macro"
+)
+
+type Interpreter interface {
+	// Define binds name to value in the global environment.
+	Define(name string, value Value)
+	// Builtin binds name to a procedure written in Go.
+	Builtin(name string, builtin Builtin)
+	Eval(form peruse.Form) (Value, error)
+	// Run evaluates every form of text and returns the
+	// value of the last one.
+	Run(text peruse.Text) (Value, error)
+}
+
+// Option configures the interpreter created by NewInterpreter.
+type Option func(*interpreter)
+
+// MaxDepth sets how deep evaluations can be nested, the default is
+// 10000. A deeper recursion fails with a diagnostic instead of
+// overflowing the stack of the host.
+func MaxDepth(depth int) Option {
+	return func(i *interpreter) {
+		i.limit = depth
+	}
+}
+
+func NewInterpreter(options ...Option) Interpreter {
+	i := &interpreter{
+		global: &environment{values: map[string]Value{}},
+		macros: macro.NewExpander(),
+		limit:  10000,
+	}
+	for _, option := range options {
+		option(i)
+	}
+	for name, builtin := range arithmetic() {
+		i.Builtin(name, builtin)
+	}
+	i.Define("true", true)
+	i.Define("false", false)
+	i.Define("nil", nil)
+	return i
+}
+
+type interpreter struct {
+	global *environment
+	macros macro.Expander
+	// depth is the number of evaluations in progress, it is
+	// at most limit.
+	depth int
+	limit int
+}
+
+type environment struct {
+	values map[string]Value
+	parent *environment
+}
+
+func (e *environment) lookup(name string) (Value, bool) {
+	for ; e != nil; e = e.parent {
+		if value, ok := e.values[name]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+type procedure struct {
+	name       string
+	parameters []string
+	body       []peruse.Form
+	env        *environment
+}
+
+func (i *interpreter) Define(name string, value Value) {
+	i.global.values[name] = value
+}
+
+func (i *interpreter) Builtin(name string, builtin Builtin) {
+	i.global.values[name] = builtin
+}
+
+func (i *interpreter) Run(text peruse.Text) (Value, error) {
+	var result Value
+	for {
+		form, err := peruse.Read(text)
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if result, err = i.Eval(form); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (i *interpreter) Eval(form peruse.Form) (Value, error) {
+	if special(form, "define-syntax") {
+		return nil, i.macros.Define(form)
+	}
+	form, err := i.macros.Expand(form)
+	if err != nil {
+		return nil, err
+	}
+	return i.eval(form, i.global)
+}
+
+// special tells whether form is a list whose head is name.
+func special(form peruse.Form, name string) bool {
+	forms := form.Forms()
+	return form.Kind() == peruse.List && len(forms) != 0 && identifier(forms[0]) && forms[0].Value() == name
+}
+
+// identifier tells whether form is a name, names such as
+// zero? or + are read as atoms.
+func identifier(form peruse.Form) bool {
+	return form.Kind() == peruse.Symbol || form.Kind() == peruse.Atom
+}
+
+func fail(form peruse.Form, format string, args ...any) error {
+	return macro.Diagnostic(form, fmt.Sprintf(format, args...))
+}
+
+func (i *interpreter) eval(form peruse.Form, env *environment) (Value, error) {
+	if i.depth++; i.depth > i.limit {
+		i.depth--
+		return nil, fail(form, "evaluation nested deeper than %d", i.limit)
+	}
+	defer func() { i.depth-- }()
+	switch form.Kind() {
+	case peruse.Integer:
+		value, err := strconv.ParseInt(form.Value(), 10, 64)
+		if err != nil {
+			return nil, fail(form, "invalid integer %s", form.Value())
+		}
+		return value, nil
+	case peruse.Float:
+		value, err := strconv.ParseFloat(form.Value(), 64)
+		if err != nil {
+			return nil, fail(form, "invalid float %s", form.Value())
+		}
+		return value, nil
+	case peruse.String, peruse.RawString:
+		return form.Value(), nil
+	case peruse.Keyword:
+		return Keyword(form.Value()), nil
+	case peruse.Symbol, peruse.Atom:
+		value, ok := env.lookup(form.Value())
+		if !ok {
+			return nil, fail(form, "undefined %s", form.Value())
+		}
+		return value, nil
+	case peruse.List:
+		return i.list(form, env)
+	}
+	return nil, fail(form, "cannot evaluate %s", form.Kind())
+}
+
+func (i *interpreter) list(form peruse.Form, env *environment) (Value, error) {
+	forms := form.Forms()
+	if len(forms) == 0 {
+		return nil, fail(form, "empty call")
+	}
+	if identifier(forms[0]) {
+		switch forms[0].Value() {
+		case "define":
+			return i.define(form, env)
+		case "lambda":
+			return i.lambda("", form, env)
+		case "if":
+			return i.conditional(form, env)
+		case "let":
+			return i.let(form, env)
+		case "quote":
+			if len(forms) != 2 {
+				return nil, fail(form, "quote expects 1 form")
+			}
+			return quote(forms[1]), nil
+		case "begin":
+			return i.body(forms[1:], env)
+		}
+	}
+	callee, err := i.eval(forms[0], env)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]Value, len(forms)-1)
+	for n, arg := range forms[1:] {
+		if args[n], err = i.eval(arg, env); err != nil {
+			return nil, err
+		}
+	}
+	return i.call(form, callee, args)
+}
+
+func (i *interpreter) call(form peruse.Form, callee Value, args []Value) (Value, error) {
+	switch p := callee.(type) {
+	case Builtin:
+		value, err := p(args)
+		if err != nil {
+			var diagnostic peruse.Diagnostic
+			if errors.As(err, &diagnostic) {
+				return nil, err
+			}
+			return nil, fail(form, "%s", err)
+		}
+		return value, nil
+	case *procedure:
+		if len(args) != len(p.parameters) {
+			return nil, fail(form, "%s expects %s, got %d", Format(p), arguments(len(p.parameters)), len(args))
+		}
+		scope := &environment{values: map[string]Value{}, parent: p.env}
+		for n, parameter := range p.parameters {
+			scope.values[parameter] = args[n]
+		}
+		return i.body(p.body, scope)
+	}
+	return nil, fail(form, "%s is not a procedure", Format(callee))
+}
+
+func arguments(n int) string {
+	if n == 1 {
+		return "1 argument"
+	}
+	return fmt.Sprintf("%d arguments", n)
+}
+
+func (i *interpreter) body(forms []peruse.Form, env *environment) (Value, error) {
+	var result Value
+	for _, form := range forms {
+		var err error
+		if result, err = i.eval(form, env); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// define handles (define name value) and (define (name parameter...) body...).
+func (i *interpreter) define(form peruse.Form, env *environment) (Value, error) {
+	forms := form.Forms()
+	if len(forms) < 2 {
+		return nil, fail(form, "define expects a name")
+	}
+	target := forms[1]
+	if target.Kind() == peruse.List {
+		if len(target.Forms()) == 0 || !identifier(target.Forms()[0]) {
+			return nil, fail(target, "define expects a name")
+		}
+		name := target.Forms()[0].Value()
+		lambda := append([]peruse.Form{forms[0], peruse.NewList(target.Forms()[1:], target.Span())}, forms[2:]...)
+		value, err := i.lambda(name, peruse.NewList(lambda, form.Span()), env)
+		if err != nil {
+			return nil, err
+		}
+		env.values[name] = value
+		return nil, nil
+	}
+	if !identifier(target) {
+		return nil, fail(target, "define expects a name")
+	}
+	if len(forms) != 3 {
+		return nil, fail(form, "define expects a name and a value")
+	}
+	value, err := i.eval(forms[2], env)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := value.(*procedure); ok && p.name == "" {
+		p.name = target.Value()
+	}
+	env.values[target.Value()] = value
+	return nil, nil
+}
+
+func (i *interpreter) lambda(name string, form peruse.Form, env *environment) (Value, error) {
+	forms := form.Forms()
+	if len(forms) < 3 || forms[1].Kind() != peruse.List {
+		return nil, fail(form, "lambda expects parameters and a body")
+	}
+	parameters := []string{}
+	for _, parameter := range forms[1].Forms() {
+		if !identifier(parameter) {
+			return nil, fail(parameter, "lambda expects a parameter name")
+		}
+		parameters = append(parameters, parameter.Value())
+	}
+	return &procedure{name, parameters, forms[2:], env}, nil
+}
+
+func (i *interpreter) conditional(form peruse.Form, env *environment) (Value, error) {
+	forms := form.Forms()
+	if len(forms) != 3 && len(forms) != 4 {
+		return nil, fail(form, "if expects a test, a consequent and an optional alternative")
+	}
+	test, err := i.eval(forms[1], env)
+	if err != nil {
+		return nil, err
+	}
+	if test != nil && test != false {
+		return i.eval(forms[2], env)
+	}
+	if len(forms) == 4 {
+		return i.eval(forms[3], env)
+	}
+	return nil, nil
+}
+
+func (i *interpreter) let(form peruse.Form, env *environment) (Value, error) {
+	forms := form.Forms()
+	if len(forms) < 3 || forms[1].Kind() != peruse.List {
+		return nil, fail(form, "let expects bindings and a body")
+	}
+	scope := &environment{values: map[string]Value{}, parent: env}
+	for _, binding := range forms[1].Forms() {
+		pair := binding.Forms()
+		if binding.Kind() != peruse.List || len(pair) != 2 || !identifier(pair[0]) {
+			return nil, fail(binding, "let expects (name value)")
+		}
+		value, err := i.eval(pair[1], env)
+		if err != nil {
+			return nil, err
+		}
+		scope.values[pair[0].Value()] = value
+	}
+	return i.body(forms[2:], scope)
+}
+
+// quote converts form to the value it reads as.
+func quote(form peruse.Form) Value {
+	switch form.Kind() {
+	case peruse.List:
+		list := List{}
+		for _, child := range form.Forms() {
+			list = append(list, quote(child))
+		}
+		return list
+	case peruse.Integer:
+		if value, err := strconv.ParseInt(form.Value(), 10, 64); err == nil {
+			return value
+		}
+	case peruse.Float:
+		if value, err := strconv.ParseFloat(form.Value(), 64); err == nil {
+			return value
+		}
+	case peruse.String, peruse.RawString:
+		return form.Value()
+	case peruse.Keyword:
+		return Keyword(form.Value())
+	}
+	return Symbol(form.Value())
+}
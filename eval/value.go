@@ -0,0 +1,152 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package eval
+
+import(
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Value is the result of an evaluation, it is one of nil, bool,
+// int64, float64, string, Symbol, Keyword, List, Builtin or a
+// procedure made by lambda.
+type Value any
+
+type Symbol string
+
+type Keyword string
+
+type List []Value
+
+// Builtin is a procedure written in Go, its error is
+// located at the call by the interpreter.
+type Builtin func(args []Value) (Value, error)
+
+// Format writes value the way it would be read.
+func Format(value Value) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case List:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = Format(item)
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	case Builtin:
+		return "#<builtin>"
+	case *procedure:
+		if v.name == "" {
+			return "#<lambda>"
+		}
+		return fmt.Sprintf("#<procedure %s>", v.name)
+	}
+	return fmt.Sprint(value)
+}
+
+// arithmetic returns the builtins every interpreter starts with.
+func arithmetic() map[string]Builtin {
+	return map[string]Builtin{
+		"+": fold("+", 0, func(a, b int64) (int64, error) { return a + b, nil }, func(a, b float64) float64 { return a + b }),
+		"*": fold("*", 1, func(a, b int64) (int64, error) { return a * b, nil }, func(a, b float64) float64 { return a * b }),
+		"-": inverse("-", 0, func(a, b int64) (int64, error) { return a - b, nil }, func(a, b float64) float64 { return a - b }),
+		"/": inverse("/", 1, func(a, b int64) (int64, error) {
+			if b == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return a / b, nil
+		}, func(a, b float64) float64 { return a / b }),
+		"=":  compare("=", func(c int) bool { return c == 0 }),
+		"<":  compare("<", func(c int) bool { return c < 0 }),
+		">":  compare(">", func(c int) bool { return c > 0 }),
+		"<=": compare("<=", func(c int) bool { return c <= 0 }),
+		">=": compare(">=", func(c int) bool { return c >= 0 }),
+	}
+}
+
+func number(name string, value Value) (int64, float64, bool, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, float64(v), false, nil
+	case float64:
+		return 0, v, true, nil
+	}
+	return 0, 0, false, fmt.Errorf("%s expects numbers, got %s", name, Format(value))
+}
+
+// apply applies the integer or the float operation to a and b.
+func apply(name string, a, b Value, ints func(a, b int64) (int64, error), floats func(a, b float64) float64) (Value, error) {
+	ai, af, afloat, err := number(name, a)
+	if err != nil {
+		return nil, err
+	}
+	bi, bf, bfloat, err := number(name, b)
+	if err != nil {
+		return nil, err
+	}
+	if afloat || bfloat {
+		return floats(af, bf), nil
+	}
+	return ints(ai, bi)
+}
+
+func fold(name string, identity int64, ints func(a, b int64) (int64, error), floats func(a, b float64) float64) Builtin {
+	return func(args []Value) (Value, error) {
+		var result Value = identity
+		for _, arg := range args {
+			var err error
+			if result, err = apply(name, result, arg, ints, floats); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+}
+
+// inverse is like fold but starts from the first argument, with one
+// argument it applies the operation to identity and that argument.
+func inverse(name string, identity int64, ints func(a, b int64) (int64, error), floats func(a, b float64) float64) Builtin {
+	return func(args []Value) (Value, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%s expects at least 1 argument", name)
+		}
+		if len(args) == 1 {
+			return apply(name, identity, args[0], ints, floats)
+		}
+		result := args[0]
+		for _, arg := range args[1:] {
+			var err error
+			if result, err = apply(name, result, arg, ints, floats); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+}
+
+func compare(name string, holds func(int) bool) Builtin {
+	return func(args []Value) (Value, error) {
+		for i := 1; i < len(args); i++ {
+			c, err := apply(name, args[i-1], args[i], func(a, b int64) (int64, error) {
+				return int64(cmp.Compare(a, b)), nil
+			}, func(a, b float64) float64 {
+				return float64(cmp.Compare(a, b))
+			})
+			if err != nil {
+				return nil, err
+			}
+			if _, f, _, _ := number(name, c); !holds(int(f)) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
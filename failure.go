@@ -0,0 +1,124 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"fmt"
+)
+
+// Reason tells why a lexeme could not be eaten, it implements error
+// so failures can be matched with errors.Is(err, peruse.InvalidChar).
+type Reason int
+
+const (
+	// NotApplicable means the text does not start with the lexeme.
+	NotApplicable Reason = iota + 1
+	EndOfText
+	Unterminated
+	InvalidChar
+	TrailingHyphen
+	MissingSeparator
+	ExtraSeparator
+	EmptyPart
+	// LimitExceeded is reported when content goes beyond
+	// the Limits of a script.
+	LimitExceeded
+	// UnknownUnit is reported for a number whose suffix is not one
+	// of the units of a script, Lexeme is the quoted suffix.
+	UnknownUnit
+)
+
+var reasons = []string{
+	NotApplicable:    "not applicable",
+	EndOfText:        "end of text",
+	Unterminated:     "unterminated",
+	InvalidChar:      "invalid character",
+	TrailingHyphen:   "trailing hyphen",
+	MissingSeparator: "missing separator",
+	ExtraSeparator:   "extra separator",
+	EmptyPart:        "empty part",
+	LimitExceeded:    "limit exceeded",
+	UnknownUnit:      "unknown unit",
+}
+
+func (r Reason) Error() string {
+	if r <= 0 || int(r) >= len(reasons) {
+		return "unknown reason"
+	}
+	return reasons[r]
+}
+
+// Failure is the error returned by the TryEat methods of Text.
+type Failure interface {
+	Diagnostic
+	Reason() Reason
+	// Lexeme is the name of what was expected (e.g. word, symbol).
+	Lexeme() string
+	// Rune is the offending rune, it is zero at the end of the text.
+	Rune() rune
+	Is(error) bool
+}
+
+func NewFailure(location Location, reason Reason, lexeme string, r rune) Failure {
+	return failure{location, reason, lexeme, r}
+}
+
+type failure struct {
+	location Location
+	reason   Reason
+	lexeme   string
+	r        rune
+}
+
+func (f failure) Location() Location {
+	return f.location
+}
+
+func (f failure) Reason() Reason {
+	return f.reason
+}
+
+func (f failure) Lexeme() string {
+	return f.lexeme
+}
+
+func (f failure) Rune() rune {
+	return f.r
+}
+
+func (f failure) Message() string {
+	switch f.reason {
+	case NotApplicable:
+		return fmt.Sprintf("expected %s, found %q", f.lexeme, f.r)
+	case EndOfText:
+		return fmt.Sprintf("expected %s, found end of text", f.lexeme)
+	case Unterminated:
+		return fmt.Sprintf("unterminated %s", f.lexeme)
+	case InvalidChar:
+		return fmt.Sprintf("invalid character %q in %s", f.r, f.lexeme)
+	case TrailingHyphen:
+		return fmt.Sprintf("%s ends with %q", f.lexeme, f.r)
+	case MissingSeparator:
+		return fmt.Sprintf("missing ':' in %s", f.lexeme)
+	case ExtraSeparator:
+		return fmt.Sprintf("unexpected %q in %s", f.r, f.lexeme)
+	case EmptyPart:
+		return fmt.Sprintf("empty part in %s", f.lexeme)
+	case LimitExceeded:
+		return fmt.Sprintf("limit exceeded: %s", f.lexeme)
+	case UnknownUnit:
+		return fmt.Sprintf("unknown unit %s", f.lexeme)
+	}
+	return fmt.Sprintf("%s in %s", f.reason, f.lexeme)
+}
+
+func (f failure) Error() string {
+	return fmt.Sprintf("%s: %s", f.location, f.Message())
+}
+
+func (f failure) Is(target error) bool {
+	reason, ok := target.(Reason)
+	return ok && reason == f.reason
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"context"
+	"io/fs"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// File is the result of parsing one file by ParseFiles.
+type File interface {
+	Path() string
+	// Tree is nil when the file could not be read or parsed.
+	Tree() Tree
+	Diagnostics() []Diagnostic
+}
+
+type file struct {
+	path        string
+	tree        Tree
+	diagnostics []Diagnostic
+}
+
+func (f *file) Path() string {
+	return f.path
+}
+
+func (f *file) Tree() Tree {
+	return f.tree
+}
+
+func (f *file) Diagnostics() []Diagnostic {
+	return f.diagnostics
+}
+
+// ParseFiles parses the files of fsys matching patterns (see fs.Glob)
+// concurrently. Files are returned sorted by path together with the
+// diagnostics of all files sorted by location, so the result does not
+// depend on the order the files were parsed in. The context is also
+// checked while a file is read, see ReadAll for the error returned.
+// Each file is read as ReadRecover does, so all of its diagnostics are
+// reported, and options are passed to Script for every file.
+func ParseFiles(ctx context.Context, fsys fs.FS, patterns []string, options ...Option) ([]File, []Diagnostic, error) {
+	paths, err := glob(fsys, patterns)
+	if err != nil {
+		return nil, nil, err
+	}
+	files := make([]*file, len(paths))
+	errs := make([]error, len(paths))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(paths)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				files[i], errs[i] = parseFile(ctx, fsys, paths[i], options)
+			}
+		}()
+	}
+	for i := range paths {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	result := make([]File, len(files))
+	diagnostics := []Diagnostic{}
+	for i, f := range files {
+		result[i] = f
+		diagnostics = append(diagnostics, f.diagnostics...)
+	}
+	SortDiagnostics(diagnostics)
+	return result, diagnostics, nil
+}
+
+func glob(fsys fs.FS, patterns []string) ([]string, error) {
+	unique := map[string]bool{}
+	paths := []string{}
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range matches {
+			if unique[path] {
+				continue
+			}
+			unique[path] = true
+			if info, err := fs.Stat(fsys, path); err == nil && info.IsDir() {
+				continue
+			}
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// parseFile returns an error only when ctx is done.
+func parseFile(ctx context.Context, fsys fs.FS, path string, options []Option) (*file, error) {
+	content, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		diagnostic := NewDiagnostic(NewLocation(path, 1, 1), err.Error())
+		return &file{path: path, diagnostics: []Diagnostic{diagnostic}}, nil
+	}
+	text := Script(path, string(content), options...)
+	r := reader{text: text, base: text.Length(), recover: true, ctx: ctx, reads: new(int)}
+	forms, extents, diagnostics, err := r.recoverAll(0)
+	if err != nil {
+		return nil, err
+	}
+	if len(diagnostics) != 0 {
+		return &file{path: path, diagnostics: diagnostics}, nil
+	}
+	t := &tree{path, string(content), forms, options, extents}
+	return &file{path: path, tree: t, diagnostics: diagnostics}, nil
+}
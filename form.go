@@ -0,0 +1,120 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"fmt"
+)
+
+// Kind identifies what a form is made of.
+type Kind int
+
+const (
+	List Kind = iota
+	Symbol
+	Keyword
+	Integer
+	Float
+	String
+	// Atom is any other run of characters that is not
+	// recognised by one of the Text classifiers (e.g. +, a.b).
+	Atom
+	// Open, Close and Comment are only found in tokens.
+	Open
+	Close
+	Comment
+	// Measure is a number with a unit, it is only read from
+	// scripts with units (see WithUnits and EatQuantity).
+	Measure
+	// RawString is a raw string or a heredoc, its value has no
+	// escapes and is printed back as a raw string.
+	RawString
+)
+
+var kinds = []string{
+	List:    "list",
+	Symbol:  "symbol",
+	Keyword: "keyword",
+	Integer: "integer",
+	Float:   "float",
+	String:  "string",
+	Atom:    "atom",
+	Open:    "open",
+	Close:   "close",
+	Comment: "comment",
+	Measure: "measure",
+	RawString: "raw string",
+}
+
+func (k Kind) String() string {
+	if k >= Custom {
+		return fmt.Sprintf("custom+%d", k-Custom)
+	}
+	if k < 0 || int(k) >= len(kinds) {
+		return "unknown"
+	}
+	return kinds[k]
+}
+
+// Form is a list or an atom read from a script.
+type Form interface {
+	Kind() Kind
+	// Value is the text of an atom, strings are returned
+	// without their quotes. Lists have no value.
+	Value() string
+	Forms() []Form
+	Span() Span
+	String() string
+}
+
+type form struct {
+	kind  Kind
+	value string
+	forms []Form
+	span  Span
+	// start and end are offsets (in runes) of the form inside
+	// the content it was read from.
+	start int
+	end   int
+}
+
+func (f form) Kind() Kind {
+	return f.kind
+}
+
+func (f form) Value() string {
+	return f.value
+}
+
+func (f form) Forms() []Form {
+	return f.forms
+}
+
+func (f form) Span() Span {
+	return f.span
+}
+
+func (f form) String() string {
+	switch f.kind {
+	case List:
+		return write(f)
+	case String:
+		return `"` + f.value + `"`
+	case RawString:
+		return rawString(f.value)
+	}
+	return f.value
+}
+
+// NewAtom makes a form of kind with value, it is meant for forms
+// built by programs (e.g. macro expansions) rather than read.
+func NewAtom(kind Kind, value string, span Span) Form {
+	return form{kind: kind, value: value, span: span}
+}
+
+// NewList makes a list of forms.
+func NewList(forms []Form, span Span) Form {
+	return form{kind: List, forms: forms, span: span}
+}
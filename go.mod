@@ -1,3 +1,5 @@
 module github.com/begopher/peruse
 
 go 1.24.1
+
+require golang.org/x/text v0.34.0
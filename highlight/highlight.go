@@ -0,0 +1,109 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+// Package highlight colours scripts the way peruse lexes them, as
+// HTML with CSS classes or as ANSI coloured terminal text. Spaces
+// between tokens are written as they are.
+//
+// Classes: paren, word, symbol, keyword, integer, float, string,
+// comment and atom. Symbols made of letters and digits only are
+// classed as words.
+package highlight
+
+import(
+	"errors"
+	"html"
+	"io"
+	"github.com/begopher/peruse"
+)
+
+// Palette maps classes to ANSI escape sequences.
+type Palette map[string]string
+
+func DefaultPalette() Palette {
+	return Palette{
+		"keyword": "\x1b[35m",
+		"integer": "\x1b[36m",
+		"float":   "\x1b[36m",
+		"measure": "\x1b[36m",
+		"string":  "\x1b[32m",
+		"comment": "\x1b[90m",
+		"atom":    "\x1b[33m",
+		"error":   "\x1b[31m",
+	}
+}
+
+// HTML writes text with every token wrapped in a span whose class is
+// the class of the token.
+func HTML(w io.Writer, text peruse.Text) error {
+	return walk(text, func(class, source string) error {
+		if class == "" {
+			_, err := io.WriteString(w, html.EscapeString(source))
+			return err
+		}
+		_, err := io.WriteString(w, `<span class="`+class+`">`+html.EscapeString(source)+`</span>`)
+		return err
+	})
+}
+
+// ANSI writes text coloured by palette, DefaultPalette is used when
+// palette is nil. Classes missing from the palette are not coloured.
+func ANSI(w io.Writer, text peruse.Text, palette Palette) error {
+	if palette == nil {
+		palette = DefaultPalette()
+	}
+	return walk(text, func(class, source string) error {
+		colour, ok := palette[class]
+		if !ok || colour == "" {
+			_, err := io.WriteString(w, source)
+			return err
+		}
+		_, err := io.WriteString(w, colour+source+"\x1b[0m")
+		return err
+	})
+}
+
+// Class returns the class of token, text is used to tell words apart
+// from symbols.
+func Class(token peruse.Token, text peruse.Text) string {
+	switch token.Kind() {
+	case peruse.Open, peruse.Close:
+		return "paren"
+	case peruse.RawString:
+		return peruse.String.String()
+	case peruse.Symbol:
+		if text.IsWord(token.Text()) {
+			return "word"
+		}
+	}
+	return token.Kind().String()
+}
+
+// walk calls emit for every token of text and for the spaces between
+// them with an empty class. When text cannot be lexed the rest of it
+// is emitted with the error class and the error is returned.
+func walk(text peruse.Text, emit func(class, source string) error) error {
+	for {
+		remain := text.Remain()
+		text.EatSpaces()
+		if spaces := remain[:len(remain)-len(text.Remain())]; spaces != "" {
+			if err := emit("", spaces); err != nil {
+				return err
+			}
+		}
+		token, err := peruse.Lex(text)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if err := emit("error", text.Remain()); err != nil {
+				return err
+			}
+			return err
+		}
+		if err := emit(Class(token, text), token.Text()); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"strings"
+	"unicode"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization is the Unicode normalization form identifiers are
+// put in before they are checked and returned.
+type Normalization int
+
+const (
+	NoNormalization Normalization = iota
+	// NFC composes characters, e.g. an e followed by a combining
+	// acute accent becomes é.
+	NFC
+	// NFKC also replaces compatibility characters, e.g. the
+	// ligature ﬁ becomes fi.
+	NFKC
+)
+
+// Case is the readtable case of identifiers, as in Common Lisp.
+type Case int
+
+const (
+	Preserve Case = iota
+	Upcase
+	Downcase
+	// Invert flips the case of identifiers written in a single
+	// case and preserves the others.
+	Invert
+)
+
+// Identifiers sets how words, symbols and keywords are read: they are
+// normalized before they are checked, and both normalized and cased
+// when they are returned. EatFunctionName compares names the same
+// way. Spans and failures still refer to the text as written.
+func Identifiers(normalization Normalization, readCase Case) Option {
+	return func(s *script) {
+		s.normalization = normalization
+		s.readCase = readCase
+	}
+}
+
+// policy tells whether identifiers are read as they are written.
+func (s *script) policy() bool {
+	return s.normalization != NoNormalization || s.readCase != Preserve
+}
+
+func (s *script) normal(value string) string {
+	switch s.normalization {
+	case NFC:
+		return norm.NFC.String(value)
+	case NFKC:
+		return norm.NFKC.String(value)
+	}
+	return value
+}
+
+func (s *script) identifier(value string) string {
+	value = s.normal(value)
+	switch s.readCase {
+	case Upcase:
+		return strings.ToUpper(value)
+	case Downcase:
+		return strings.ToLower(value)
+	case Invert:
+		upper, lower := false, false
+		for _, r := range value {
+			upper = upper || unicode.IsUpper(r)
+			lower = lower || unicode.IsLower(r)
+		}
+		switch {
+		case upper && !lower:
+			return strings.ToLower(value)
+		case lower && !upper:
+			return strings.ToUpper(value)
+		}
+	}
+	return value
+}
+
+// identify runs eat on a copy of the script, without identifier
+// policy, over the normalized identifier content starts with. When
+// eat takes all of it the identifier as written is skipped and the
+// values eat returns are cased.
+func (s *script) identify(eat func(*script) []string) []string {
+	raw := s.until()
+	t := *s
+	t.normalization, t.readCase = NoNormalization, Preserve
+	t.content = s.normal(raw)
+	t.length = len([]rune(t.content))
+	values := eat(&t)
+	if values[0] == "" || !t.Empty() {
+		return make([]string, len(values))
+	}
+	s.skip(len(raw))
+	for i, value := range values {
+		values[i] = s.identifier(value)
+	}
+	return values
+}
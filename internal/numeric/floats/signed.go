@@ -6,6 +6,7 @@ package floats
 
 import(
 	"unicode"
+	"unicode/utf8"
 	"github.com/begopher/peruse/internal/numeric"
 )
 
@@ -15,34 +16,37 @@ func Signed() numeric.Number {
 
 type signed struct {}
 
-func (signed) Scan(content []rune) []rune {
+func (signed) Scan(content string) string {
 	required := 3
 	if len(content) < required {
-		return []rune{}
+		return ""
 	}	
 	signed := content[0] 
-	if  signed != rune('-') && signed != rune('+') {
-		return []rune{}
+	if  signed != '-' && signed != '+' {
+		return ""
 	}
-	offset := 1
+	// count is in runes and offset in bytes
+	count, offset := 1, 1
 	dots := 0
 	for _, d := range content[1:] {
 		if d == '.' {
 			dots++
-			if dots > 1 { return []rune{} }
+			if dots > 1 { return "" }
+			count++
 			offset++
 			continue
 		}
-		if d == ' ' || d == ')' || d == '\n' {
+		if numeric.Delimiter(d) {
 			break
 		}		
 		if !unicode.IsDigit(d) {
-			return []rune{}
+			return ""
 		}		
-		offset++
+		count++
+		offset += utf8.RuneLen(d)
 	}	
-	if offset < required {
-		return []rune{}
+	if count < required {
+		return ""
 	}	
 	return content[:offset]	
 }
@@ -6,6 +6,7 @@ package floats
 
 import(
 	"unicode"
+	"unicode/utf8"
 	"github.com/begopher/peruse/internal/numeric"
 )
 
@@ -15,29 +16,32 @@ func Unsigned() numeric.Number {
 
 type unsigned struct {}
 
-func (unsigned) Scan(content []rune) []rune {
+func (unsigned) Scan(content string) string {
 	required := 2
 	if len(content) < required {
-		return []rune{}
+		return ""
 	}	
-	offset, dots := 0, 0
+	// count is in runes and offset in bytes
+	count, offset, dots := 0, 0, 0
 	for _, d := range content {
 		if d == '.' {
 			dots++
-			if dots > 1 { return []rune{} }
+			if dots > 1 { return "" }
+			count++
 			offset++
 			continue
 		}
-		if d == ' ' || d == ')' || d == '\n' {
+		if numeric.Delimiter(d) {
 			break
 		}		
 		if !unicode.IsDigit(d) {
-			return []rune{}
+			return ""
 		}		
-		offset++
+		count++
+		offset += utf8.RuneLen(d)
 	}	
-	if offset < required {
-		return []rune{}
+	if count < required {
+		return ""
 	}	
 	return content[:offset]	
 }
@@ -6,6 +6,7 @@ package ints
 
 import(
 	"unicode"
+	"unicode/utf8"
 	"github.com/begopher/peruse/internal/numeric"
 )
 
@@ -15,26 +16,26 @@ func Signed() numeric.Number {
 
 type signed struct {}
 
-func (d signed) Scan(content []rune) []rune {
+func (d signed) Scan(content string) string {
 	if len(content) < 2 {
-		return []rune{}
+		return ""
 	}
 	signed := content[0] 
-	if  signed != rune('-') && signed != rune('+') {
-		return []rune{}
+	if  signed != '-' && signed != '+' {
+		return ""
 	}
 	offset := 1
 	for _, d := range content[1:] {
-		if d == ' ' || d == ')' || d == '\n' {
+		if numeric.Delimiter(d) {
 			break
 		}
 		if !unicode.IsDigit(d) {
-			return []rune{}
+			return ""
 		}
-		offset++
+		offset += utf8.RuneLen(d)
 	}	
 	if offset == 1 {
-		return []rune{}
+		return ""
 	}
 	
 	return content[:offset]	
@@ -6,6 +6,7 @@ package ints
 
 import(
 	"unicode"
+	"unicode/utf8"
 	"github.com/begopher/peruse/internal/numeric"
 )
 
@@ -15,20 +16,19 @@ func Unsigned() numeric.Number {
 
 type unsigned struct {}
 
-func (d unsigned) Scan(content []rune) []rune {
+func (d unsigned) Scan(content string) string {
 	offset := 0
 	for _, d := range content {
-		if d == ' ' || d == ')' || d == '\n' {
+		if numeric.Delimiter(d) {
 			break
 		}
 		if !unicode.IsDigit(d) {
-			return []rune{}
+			return ""
 		}
-		offset++
+		offset += utf8.RuneLen(d)
 	}
 	if offset == 0 {
-		return []rune{}
+		return ""
 	}
 	return content[:offset]	
 }
-
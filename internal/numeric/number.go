@@ -4,7 +4,19 @@
 
 package numeric
 
+import(
+	"unicode"
+)
+
+// Number returns the prefix of content that forms a number, or an
+// empty string. The prefix shares the memory of content.
 type Number interface {
-	Scan(content []rune) []rune
+	Scan(content string) string
 }
 
+// Delimiter tells whether r ends a token, it is shared by the lexer
+// and by the scanners so a token is classified the same whatever
+// follows it.
+func Delimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' || r == ';'
+}
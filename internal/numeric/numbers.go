@@ -12,12 +12,12 @@ type numbers struct {
 	many []Number
 }
 
-func (n numbers) Scan(content []rune) []rune {
+func (n numbers) Scan(content string) string {
 	for _, number := range n.many {
 		if result := number.Scan(content); len(result) != 0 {
 			return result
 		}
 	}
-	return []rune{}
+	return ""
 }
 
@@ -0,0 +1,68 @@
+package test
+
+import(
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+var benchmark = strings.Repeat("(define (area width height) (multiply width height 1.5 :meters \"a string\"))\n", 200)
+
+func BenchmarkScript(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		peruse.Script("bench", benchmark)
+	}
+}
+
+func BenchmarkRemain(b *testing.B) {
+	text := peruse.Script("bench", benchmark)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		text.Remain()
+	}
+}
+
+func BenchmarkBeginWith(b *testing.B) {
+	text := peruse.Script("bench", benchmark)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		text.BeginWith("(define")
+	}
+}
+
+func BenchmarkEat(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		text := peruse.Script("bench", benchmark)
+		for {
+			text.EatSpaces()
+			if text.Empty() {
+				break
+			}
+			if text.Eat("(") || text.Eat(")") {
+				continue
+			}
+			if text.EatSymbol() != "" || text.EatKeyword() != "" {
+				continue
+			}
+			if text.EatInteger() != "" || text.EatFloat() != "" {
+				continue
+			}
+			if _, ok := text.EatString(); !ok {
+				b.Fatalf("unexpected content at %s", text.Location())
+			}
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := peruse.Parse("bench", benchmark); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
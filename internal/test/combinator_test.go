@@ -0,0 +1,188 @@
+package test
+
+import(
+	"strings"
+	"sync"
+	"testing"
+	"github.com/begopher/peruse"
+	c "github.com/begopher/peruse/combinator"
+)
+
+// define is the grammar of (define name expr...), an expr is an
+// integer, a symbol or a list of exprs.
+func define() c.Parser[[]string] {
+	var expr c.Parser[string]
+	list := c.Lazy(func() c.Parser[string] {
+		return c.Map(
+			c.Between(c.Literal("("), c.Many(expr), c.Literal(")")),
+			func(exprs []string) string { return "(" + strings.Join(exprs, " ") + ")" },
+		)
+	})
+	expr = c.Alt(c.Integer(), c.Symbol(), list)
+	head := c.Seq(c.Literal("("), c.Name("define"), c.Symbol())
+	return func(text peruse.Text) ([]string, error) {
+		names, err := head(text)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.Many1(expr)(text)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := c.Literal(")")(text); err != nil {
+			return nil, err
+		}
+		return append([]string{names[2]}, body...), nil
+	}
+}
+
+func TestCombinator(t *testing.T) {
+	table := []struct {
+		content string
+		values []string
+		remain string
+		err string
+	}{
+		{
+			content: "(define x 1) y",
+			values: []string{"x", "1"},
+			remain: " y",
+		},
+		{
+			content: " ; square\n(define square (mul x x)\n  (add 1 (neg 2)))",
+			values: []string{"square", "(mul x x)", "(add 1 (neg 2))"},
+		},
+		{
+			content: "(define 1 2)",
+			err: "f:1:9: expected symbol, found '1'",
+		},
+		{
+			content: "(defined x 1)",
+			err: "f:1:2: expected define",
+		},
+		{
+			content: "(define x)",
+			err: "f:1:10: expected integer, found ')'",
+		},
+		{
+			content: "(define x (add 1 \"s\"))",
+			err: `f:1:18: expected ")"`,
+		},
+		{
+			content: "(define x 1",
+			err: `f:1:12: expected ")"`,
+		},
+	}
+	for _, row := range table {
+		text := peruse.Script("f", row.content)
+		values, err := define()(text)
+		if row.err != "" {
+			if err == nil || err.Error() != row.err {
+				t.Errorf("%q: got(%v) expected (%s)", row.content, err, row.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", row.content, err)
+			continue
+		}
+		if got, expected := strings.Join(values, "|"), strings.Join(row.values, "|"); got != expected {
+			t.Errorf("got(%s) expected (%s)", got, expected)
+		}
+		if got := text.Remain(); got != row.remain {
+			t.Errorf("remain: got(%q) expected (%q)", got, row.remain)
+		}
+	}
+}
+
+func TestCombinatorSepBy(t *testing.T) {
+	table := []struct {
+		content string
+		values []string
+		err string
+	}{
+		{"", []string{}, ""},
+		{"a", []string{"a"}, ""},
+		{"a , b ,c", []string{"a", "b", "c"}, ""},
+		{"a , 1", nil, "f:1:5: expected symbol, found '1'"},
+	}
+	parser := c.SepBy(c.Symbol(), c.Literal(","))
+	for _, row := range table {
+		values, err := parser(peruse.Script("f", row.content))
+		if row.err != "" {
+			if err == nil || err.Error() != row.err {
+				t.Errorf("%q: got(%v) expected (%s)", row.content, err, row.err)
+			}
+			continue
+		}
+		if got, expected := strings.Join(values, "|"), strings.Join(row.values, "|"); err != nil || got != expected {
+			t.Errorf("%q: got(%s, %v) expected (%s)", row.content, got, err, expected)
+		}
+	}
+}
+
+func TestCombinatorOptional(t *testing.T) {
+	parser := c.Seq(c.Optional(c.Keyword()), c.Symbol(), c.End())
+	text := peruse.Script("f", "name ; comment\n")
+	values, err := c.Map(parser, func(values []string) string { return values[0] + "|" + values[1] })(text)
+	if err != nil || values != "|name" {
+		t.Errorf("got(%q, %v) expected (%q)", values, err, "|name")
+	}
+	if _, err := parser(peruse.Script("f", ":k name extra")); err == nil || err.Error() != "f:1:9: expected end of text" {
+		t.Errorf("got(%v) expected (%s)", err, "f:1:9: expected end of text")
+	}
+}
+
+func TestCombinatorNameMismatch(t *testing.T) {
+	table := []struct {
+		name string
+		parser c.Parser[[]string]
+		content string
+		values []string
+		remain string
+	}{
+		{
+			name: "Optional",
+			parser: c.Map(c.Optional(c.Name("else")), func(value string) []string { return []string{value} }),
+			content: "foo bar",
+			values: []string{""},
+			remain: "foo bar",
+		},
+		{name: "Many", parser: c.Many(c.Name("x")), content: "x x y", values: []string{"x", "x"}, remain: " y"},
+		{name: "SepBy", parser: c.SepBy(c.Name("x"), c.Literal(",")), content: "x , x y", values: []string{"x", "x"}, remain: " y"},
+		{name: "SepBy", parser: c.SepBy(c.Name("x"), c.Literal(",")), content: "y , x", values: []string{}, remain: "y , x"},
+	}
+	for _, row := range table {
+		text := peruse.Script("f", row.content)
+		values, err := row.parser(text)
+		if err != nil {
+			t.Errorf("%s(%q) returns unexpected error (%v)", row.name, row.content, err)
+			continue
+		}
+		if got, expected := strings.Join(values, "|"), strings.Join(row.values, "|"); got != expected {
+			t.Errorf("%s(%q): got(%s) expected (%s)", row.name, row.content, got, expected)
+		}
+		if got, expected := text.Remain(), row.remain; got != expected {
+			t.Errorf("%s(%q) remain: got(%q) expected (%q)", row.name, row.content, got, expected)
+		}
+	}
+}
+
+func TestLazyShared(t *testing.T) {
+	parser := define()
+	var wait sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			_, errs[i] = parser(peruse.Script("f", "(define f (g (h 1)))"))
+		}()
+	}
+	wait.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Errorf("got(%v) expected no error", err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package test
+
+import(
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestReaderConditionals(t *testing.T) {
+	table := []struct {
+		content string
+		features []string
+		forms string
+		skipped []string
+		err string
+	}{
+		{content: "(a #+linux b #-linux c d)", features: []string{"linux"}, forms: "(a b d)", skipped: []string{"f:1:14-1:23"}},
+		{content: "(#+(and linux (not windows)) x #+(or mac bsd) y)", features: []string{"linux"}, forms: "(x)", skipped: []string{"f:1:32-1:48"}},
+		{content: "#?(:clj 1 :cljs 2)", features: []string{"cljs"}, forms: "2", skipped: []string{"f:1:4-1:10"}},
+		{content: "(list #?@(:linux (1 2) :default (3)))", features: []string{"linux"}, forms: "(list 1 2)", skipped: []string{"f:1:24-1:36"}},
+		{content: "(list #?@(:mac (1 2) :default (3)))", forms: "(list 3)", skipped: []string{"f:1:11-1:21"}},
+		{content: "#+mac (a)\n(b)\n#?(:mac c)", forms: "(b)", skipped: []string{"f:1:1-1:10", "f:3:4-3:10"}},
+		{content: "#+mac (a #+linux b)", features: []string{"linux"}, skipped: []string{"f:1:1-1:20"}},
+		{content: "(#+linux b)", forms: "()", skipped: []string{"f:1:2-1:11"}},
+		{content: "#-linux (a", features: []string{"linux"}, err: "f:1:9: unclosed '('"},
+		{content: "#?@(:linux (1))", features: []string{"linux"}, err: "f:1:1: #?@ splices outside of a list"},
+		{content: "(#?@(:linux 1))", features: []string{"linux"}, err: "f:1:13: #?@ expects a list to splice, found integer"},
+		{content: "(#+(xor a) b)", err: "f:1:4: invalid feature expression (xor a)"},
+		{content: "(#+(not a b) c)", err: "f:1:4: not expects 1 feature expression"},
+		{content: "(#?(:linux))", err: "f:1:5: missing form after feature in #?"},
+		{content: "(#+linux)", features: []string{"linux"}, err: "f:1:9: missing form after #+"},
+		{content: "(#+)", err: "f:1:4: missing feature expression after #+"},
+		{content: "#? x", err: "f:1:4: expected '(' after #?"},
+	}
+	for _, data := range table {
+		skipped := []string{}
+		options := []peruse.Option{
+			peruse.Features(data.features...),
+			peruse.Skipped(func(span peruse.Span) { skipped = append(skipped, span.String()) }),
+		}
+		tree, err := peruse.Parse("f", data.content, options...)
+		if data.err != "" {
+			if err == nil || err.Error() != data.err {
+				t.Errorf("Parse(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		forms := []string{}
+		for _, form := range tree.Forms() {
+			forms = append(forms, form.String())
+		}
+		if got := strings.Join(forms, " "); got != data.forms {
+			t.Errorf("Parse(%q): got(%s) expected (%s)", data.content, got, data.forms)
+		}
+		if strings.Join(skipped, " ") != strings.Join(data.skipped, " ") {
+			t.Errorf("Parse(%q) skipped: got(%v) expected (%v)", data.content, skipped, data.skipped)
+		}
+	}
+}
+
+func TestReaderConditionalsDisabled(t *testing.T) {
+	form, err := peruse.Read(peruse.Script("f", "(#+linux b)"))
+	if err != nil || form.String() != "(#+linux b)" {
+		t.Errorf("Read: got(%v, %v) expected ((#+linux b))", form, err)
+	}
+}
+
+func TestReaderConditionalsSkipped(t *testing.T) {
+	table := []struct {
+		content string
+		forms string
+	}{
+		{content: "(list #+windows #0=(a) #-windows #0=(b) #0#)", forms: "(list #0=(b) #0#)"},
+		{content: "(list #+windows #0# 1)", forms: "(list 1)"},
+		{content: "(a #+windows (b c #0=(d #0#)))", forms: "(a)"},
+	}
+	for _, data := range table {
+		options := []peruse.Option{
+			peruse.Features("linux"),
+			peruse.DatumLabels(),
+			peruse.WithLimits(peruse.Limits{Forms: 7}),
+		}
+		tree, err := peruse.Parse("f", data.content, options...)
+		if err != nil {
+			t.Errorf("Parse(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		if got := tree.Forms()[0].String(); got != data.forms {
+			t.Errorf("Parse(%q): got(%s) expected (%s)", data.content, got, data.forms)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package test
+
+import(
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"github.com/begopher/peruse"
+)
+
+// countdown is a context that is canceled once Err has been
+// called more than n times.
+type countdown struct {
+	context.Context
+	n int
+}
+
+func (c *countdown) Err() error {
+	if c.n--; c.n < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestReadAllContext(t *testing.T) {
+	// a small form followed by a form of 1000 atoms
+	content := "(a)\n(" + strings.Repeat("x ", 1000) + ")\n(b)"
+	table := []struct {
+		n int
+		forms int
+		err string
+	}{
+		{n: 0, forms: 0, err: "f:1:1: context canceled"},
+		{n: 1, forms: 1, err: "f:1:4: context canceled"},
+		// the large form is left after reading 256 of its atoms
+		{n: 2, forms: 1, err: "f:2:1: context canceled"},
+		{n: 100, forms: 3},
+	}
+	for _, row := range table {
+		text := peruse.Script("f", content)
+		forms, err := peruse.ReadAll(&countdown{context.Background(), row.n}, text)
+		if len(forms) != row.forms {
+			t.Errorf("%d: got(%d forms) expected (%d)", row.n, len(forms), row.forms)
+		}
+		if row.err == "" {
+			if err != nil {
+				t.Errorf("%d: unexpected error %s", row.n, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != row.err || !errors.Is(err, context.Canceled) {
+			t.Errorf("%d: got(%v) expected (%s)", row.n, err, row.err)
+			continue
+		}
+		rest, err := peruse.ReadAll(context.Background(), text)
+		if err != nil || len(forms)+len(rest) != 3 {
+			t.Errorf("%d: resume got(%d forms, %v) expected (%d)", row.n, len(rest), err, 3-len(forms))
+		}
+	}
+}
+
+func TestParseFilesContext(t *testing.T) {
+	fsys := fstest.MapFS{"a.lisp": {Data: []byte("(" + strings.Repeat("x ", 1000) + ")")}}
+	ctx := &countdown{context.Background(), 1}
+	_, _, err := peruse.ParseFiles(ctx, fsys, []string{"*.lisp"})
+	if err == nil || err.Error() != "a.lisp:1:1: context canceled" || !errors.Is(err, context.Canceled) {
+		t.Errorf("got(%v) expected (a.lisp:1:1: context canceled)", err)
+	}
+}
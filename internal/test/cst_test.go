@@ -0,0 +1,125 @@
+package test
+
+import(
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestCSTRoundTrip(t *testing.T) {
+	table := []string{
+		"",
+		"   \n\n",
+		"; only a comment",
+		"(define x 1)",
+		"  ( define  x\t1 ) ; trailing\n",
+		"; header\n\n(define (f a b)\n  ; body\n  (add a b)) ; done\n(f 1 2)\n\n; footer",
+		"(a (b (c \"s t\" :k 1.5 -2)) ( ) )",
+		"(λ ×\n\t\"multi\nline\")\r\n(x)",
+		"a b\tc",
+	}
+	for _, content := range table {
+		tree, err := peruse.ParseCST("f", content)
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", content, err)
+			continue
+		}
+		if got := tree.String(); got != content {
+			t.Errorf("got(%q) expected (%q)", got, content)
+		}
+	}
+}
+
+func TestCSTTrivia(t *testing.T) {
+	tree, err := peruse.ParseCST("f", "; c\n(f a ; note\n  b)  ; end\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := tree.Nodes()[0]
+	a, b := f.Children()[1], f.Children()[2]
+	table := []struct {
+		name string
+		got string
+		expected string
+	}{
+		{"f leading", f.Leading(), "; c\n"},
+		{"f trailing", f.Trailing(), "  ; end"},
+		{"a text", a.Text(), "a"},
+		{"a leading", a.Leading(), " "},
+		{"a trailing", a.Trailing(), " ; note"},
+		{"b leading", b.Leading(), "\n  "},
+		{"b span", b.Span().String(), "f:3:3-3:4"},
+		{"tree trailing", tree.Trailing(), "\n"},
+		{"form", f.Form().String(), "(f a b)"},
+	}
+	for _, row := range table {
+		if row.got != row.expected {
+			t.Errorf("%s: got(%q) expected (%q)", row.name, row.got, row.expected)
+		}
+	}
+}
+
+func TestCSTEdit(t *testing.T) {
+	content := "; keep\n(define  x\n  (add 1 2)) ; comment\n"
+	tree, err := peruse.ParseCST("f", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	define := tree.Nodes()[0]
+	add := define.Children()[2]
+	table := []struct {
+		name string
+		edit func() (peruse.CST, error)
+		expected string
+	}{
+		{
+			name: "replace",
+			edit: func() (peruse.CST, error) { return tree.Replace(define.Children()[1], "y") },
+			expected: "; keep\n(define  y\n  (add 1 2)) ; comment\n",
+		},
+		{
+			name: "replace list",
+			edit: func() (peruse.CST, error) { return tree.Replace(add, "(mul 3\n     4)") },
+			expected: "; keep\n(define  x\n  (mul 3\n     4)) ; comment\n",
+		},
+		{
+			name: "remove",
+			edit: func() (peruse.CST, error) { return tree.Remove(add.Children()[1]) },
+			expected: "; keep\n(define  x\n  (add 2)) ; comment\n",
+		},
+		{
+			name: "remove first",
+			edit: func() (peruse.CST, error) { return tree.Remove(add.Children()[0]) },
+			expected: "; keep\n(define  x\n  (1 2)) ; comment\n",
+		},
+		{
+			name: "insert",
+			edit: func() (peruse.CST, error) { return tree.InsertAfter(add.Children()[2], " 3") },
+			expected: "; keep\n(define  x\n  (add 1 2 3)) ; comment\n",
+		},
+		{
+			name: "insert before",
+			edit: func() (peruse.CST, error) { return tree.InsertBefore(define, "(x)\n") },
+			expected: "; keep\n(x)\n(define  x\n  (add 1 2)) ; comment\n",
+		},
+	}
+	for _, row := range table {
+		edited, err := row.edit()
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", row.name, err)
+			continue
+		}
+		if got := edited.String(); got != row.expected {
+			t.Errorf("%s: got(%q) expected (%q)", row.name, got, row.expected)
+		}
+	}
+	if got := tree.String(); got != content {
+		t.Errorf("tree was changed: got(%q)", got)
+	}
+	if _, err := tree.Replace(add, "(oops"); err == nil {
+		t.Errorf("expected an error for an unclosed list")
+	}
+	other, _ := peruse.ParseCST("f", content)
+	if _, err := tree.Remove(other.Nodes()[0]); err == nil {
+		t.Errorf("expected an error for a node of another tree")
+	}
+}
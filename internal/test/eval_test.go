@@ -0,0 +1,116 @@
+package test
+
+import(
+	"fmt"
+	"testing"
+	"github.com/begopher/peruse"
+	"github.com/begopher/peruse/eval"
+)
+
+func TestEval(t *testing.T) {
+	table := []struct {
+		content string
+		expected string
+		err string
+	}{
+		{content: "(+ 1 2 3)", expected: "6"},
+		{content: "(- 10 1 2)", expected: "7"},
+		{content: "(- 5)", expected: "-5"},
+		{content: "(* 2 1.5)", expected: "3"},
+		{content: "(/ 7 2)", expected: "3"},
+		{content: "(< 1 2 3)", expected: "true"},
+		{content: "(>= 1 2)", expected: "false"},
+		{content: `(begin "a" "b")`, expected: `"b"`},
+		{content: "(quote (a 1 :k (2.5 \"s\")))", expected: `(a 1 :k (2.5 "s"))`},
+		{content: "(if false 1 2)", expected: "2"},
+		{content: "(if nil 1)", expected: "nil"},
+		{content: "(if 0 1 2)", expected: "1"},
+		{content: "(let ((x 2) (y 3)) (* x y))", expected: "6"},
+		{
+			content: "(define (fact n) (if (= n 0) 1 (* n (fact (- n 1)))))\n(fact 10)",
+			expected: "3628800",
+		},
+		{
+			content: "(define x 1)\n(define (get) x)\n(let ((x 2)) (get))",
+			expected: "1",
+		},
+		{
+			content: "(define (adder n) (lambda (x) (+ x n)))\n(define add2 (adder 2))\n(add2 40)",
+			expected: "42",
+		},
+		{content: "(define (f) 1)\nf", expected: "#<procedure f>"},
+		{content: "(double 21)", expected: "42"},
+		{
+			content: "(define-syntax unless\n  (syntax-rules ()\n    ((_ c e) (if c nil e))))\n(unless false 7)",
+			expected: "7",
+		},
+		{content: "(+ 1 y)", err: "f:1:6: undefined y"},
+		{content: "(define (f x)\n  (/ x 0))\n(f 1)", err: "f:2:3: division by zero"},
+		{content: "(+ 1 \"a\")", err: `f:1:1: + expects numbers, got "a"`},
+		{content: "(1 2)", err: "f:1:1: 1 is not a procedure"},
+		{content: "(define (f x) x)\n(f)", err: "f:2:1: #<procedure f> expects 1 argument, got 0"},
+		{content: "(define 1 2)", err: "f:1:9: define expects a name"},
+		{content: "(let (x) x)", err: "f:1:7: let expects (name value)"},
+		{content: "(fail)", err: "f:1:1: failed"},
+		{
+			content: "(define-syntax div0\n  (syntax-rules ()\n    ((_ x) (/ x 0))))\n(div0 1)",
+			err: "f:3:12: division by zero (expanded from f:4:1)",
+		},
+	}
+	for _, row := range table {
+		interpreter := eval.NewInterpreter()
+		interpreter.Builtin("double", func(args []eval.Value) (eval.Value, error) {
+			return args[0].(int64) * 2, nil
+		})
+		interpreter.Builtin("fail", func(args []eval.Value) (eval.Value, error) {
+			return nil, fmt.Errorf("failed")
+		})
+		value, err := interpreter.Run(peruse.Script("f", row.content))
+		if row.err != "" {
+			if err == nil || err.Error() != row.err {
+				t.Errorf("%q: got(%v) expected (%s)", row.content, err, row.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", row.content, err)
+			continue
+		}
+		if got := eval.Format(value); got != row.expected {
+			t.Errorf("%q: got(%s) expected (%s)", row.content, got, row.expected)
+		}
+	}
+}
+
+func TestEvalDepth(t *testing.T) {
+	loop := "(define (loop n)\n  (+ 1 (loop n)))\n(loop 1)"
+	table := []struct {
+		options []eval.Option
+		content string
+		expected string
+		err string
+	}{
+		{content: loop, err: "f:2:4: evaluation nested deeper than 10000"},
+		{options: []eval.Option{eval.MaxDepth(50)}, content: loop, err: "f:2:4: evaluation nested deeper than 50"},
+		{options: []eval.Option{eval.MaxDepth(50)}, content: "(define (down n) (if (= n 0) 0 (down (- n 1))))\n(down 5)", expected: "0"},
+	}
+	for _, row := range table {
+		value, err := eval.NewInterpreter(row.options...).Run(peruse.Script("f", row.content))
+		if row.err != "" {
+			if err == nil || err.Error() != row.err {
+				t.Errorf("%q: got(%v) expected (%s)", row.content, err, row.err)
+			}
+			continue
+		}
+		if err != nil || eval.Format(value) != row.expected {
+			t.Errorf("%q: got(%v, %v) expected (%s)", row.content, value, err, row.expected)
+		}
+	}
+}
+
+func TestEvalCyclic(t *testing.T) {
+	_, err := eval.NewInterpreter().Run(peruse.Script("f", "(quote #0=(a #0#))", peruse.DatumLabels()))
+	if got, expected := fmt.Sprint(err), "f:1:1: form contains itself and cannot be expanded"; got != expected {
+		t.Errorf("got(%s) expected (%s)", got, expected)
+	}
+}
@@ -0,0 +1,106 @@
+package test
+
+import(
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"github.com/begopher/peruse"
+)
+
+func TestParseFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"lib/b.lisp": {Data: []byte("(define b 2)\n)")},
+		"lib/a.lisp": {Data: []byte("(define a 1)")},
+		"main.lisp": {Data: []byte("(print \"a)\n(b")},
+		"notes.txt": {Data: []byte("(")},
+	}
+	for i := 0; i < 100; i++ {
+		fsys[fmt.Sprintf("gen/%03d.lisp", i)] = &fstest.MapFile{Data: []byte(fmt.Sprintf("(f %d)\n  (", i))}
+	}
+	files, diagnostics, err := peruse.ParseFiles(context.Background(), fsys, []string{"*.lisp", "lib/*.lisp", "gen/*.lisp", "lib/a.lisp"})
+	if err != nil {
+		t.Fatalf("ParseFiles returns unexpected error (%v)", err)
+	}
+	if got, expected := len(files), 103; got != expected {
+		t.Fatalf("ParseFiles returns (%d) files expected (%d)", got, expected)
+	}
+	if got, expected := files[0].Path(), "gen/000.lisp"; got != expected {
+		t.Errorf("ParseFiles first file: got(%s) expected (%s)", got, expected)
+	}
+	a := files[100]
+	if got, expected := a.Path(), "lib/a.lisp"; got != expected {
+		t.Errorf("ParseFiles file: got(%s) expected (%s)", got, expected)
+	}
+	if a.Tree() == nil || len(a.Tree().Forms()) != 1 || len(a.Diagnostics()) != 0 {
+		t.Errorf("ParseFiles does not parse (%s) correctly", a.Path())
+	}
+	if files[101].Tree() != nil || len(files[101].Diagnostics()) != 1 {
+		t.Errorf("ParseFiles does not report the error of (%s)", files[101].Path())
+	}
+	if files[102].Tree() != nil || len(files[102].Diagnostics()) != 2 {
+		t.Errorf("ParseFiles does not report all errors of (%s)", files[102].Path())
+	}
+	if got, expected := len(diagnostics), 103; got != expected {
+		t.Fatalf("ParseFiles returns (%d) diagnostics expected (%d)", got, expected)
+	}
+	expected := []string{"gen/000.lisp:2:3: unclosed '('", "gen/001.lisp:2:3: unclosed '('"}
+	for i, e := range expected {
+		if got := diagnostics[i].Error(); got != e {
+			t.Errorf("ParseFiles diagnostic (%d): got(%s) expected (%s)", i, got, e)
+		}
+	}
+	expected = []string{"lib/b.lisp:2:1: unexpected ')'", "main.lisp:1:8: unterminated string", "main.lisp:2:1: unclosed '('"}
+	for i, e := range expected {
+		if got := diagnostics[100+i].Error(); got != e {
+			t.Errorf("ParseFiles diagnostic (%d): got(%s) expected (%s)", 100+i, got, e)
+		}
+	}
+}
+
+func TestParseFilesOptions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.lisp": {Data: []byte("#+unix (a) #-unix (b)\n(c)")},
+		"b.lisp": {Data: []byte("(abcdef)\n(abc)")},
+	}
+	files, diagnostics, err := peruse.ParseFiles(context.Background(), fsys, []string{"*.lisp"}, peruse.Features("unix"), peruse.WithLimits(peruse.Limits{Token: 4}))
+	if err != nil {
+		t.Fatalf("ParseFiles returns unexpected error (%v)", err)
+	}
+	if tree := files[0].Tree(); tree == nil || len(tree.Forms()) != 2 || tree.Forms()[0].String() != "(a)" {
+		t.Errorf("ParseFiles does not pass features to (%s)", files[0].Path())
+	}
+	if got, expected := len(diagnostics), 1; got != expected {
+		t.Fatalf("ParseFiles returns (%d) diagnostics expected (%d)", got, expected)
+	}
+	if got, expected := diagnostics[0].Location().String(), "b.lisp:1:2"; got != expected {
+		t.Errorf("ParseFiles diagnostic: got(%s) expected (%s)", got, expected)
+	}
+}
+
+func TestParseFilesCanceled(t *testing.T) {
+	fsys := fstest.MapFS{"a.lisp": {Data: []byte("(a)")}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := peruse.ParseFiles(ctx, fsys, []string{"*.lisp"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseFiles returns (%v) expected (%v)", err, context.Canceled)
+	}
+}
+
+func TestSortDiagnostics(t *testing.T) {
+	diagnostics := []peruse.Diagnostic{
+		peruse.NewDiagnostic(peruse.NewLocation("b", 1, 1), "x"),
+		peruse.NewDiagnostic(peruse.NewLocation("a", 2, 1), "x"),
+		peruse.NewDiagnostic(peruse.NewLocation("a", 1, 9), "x"),
+		peruse.NewDiagnostic(peruse.NewLocation("a", 1, 9), "a"),
+		peruse.NewDiagnostic(peruse.NewLocation("a", 1, 10), "x"),
+	}
+	peruse.SortDiagnostics(diagnostics)
+	expected := []string{"a:1:9: a", "a:1:9: x", "a:1:10: x", "a:2:1: x", "b:1:1: x"}
+	for i, e := range expected {
+		if got := diagnostics[i].Error(); got != e {
+			t.Errorf("SortDiagnostics (%d): got(%s) expected (%s)", i, got, e)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package test
+
+import(
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+	"github.com/begopher/peruse/highlight"
+)
+
+func TestHighlightHTML(t *testing.T) {
+	content := "(define my-name 12 ; a <comment>\n  \"a&b\" :key 1.5 +)"
+	var b strings.Builder
+	if err := highlight.HTML(&b, peruse.Script("any", content)); err != nil {
+		t.Fatalf("HTML returns unexpected error (%v)", err)
+	}
+	expected := `<span class="paren">(</span><span class="word">define</span> ` +
+		`<span class="symbol">my-name</span> <span class="integer">12</span> ` +
+		`<span class="comment">; a &lt;comment&gt;</span>` + "\n  " +
+		`<span class="string">&#34;a&amp;b&#34;</span> <span class="keyword">:key</span> ` +
+		`<span class="float">1.5</span> <span class="atom">+</span><span class="paren">)</span>`
+	if got := b.String(); got != expected {
+		t.Errorf("HTML: got\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestHighlightANSI(t *testing.T) {
+	content := "(f :k \"s\") ; c\n"
+	var b strings.Builder
+	palette := highlight.Palette{"keyword": "<k>", "string": "<s>", "comment": "<c>"}
+	if err := highlight.ANSI(&b, peruse.Script("any", content), palette); err != nil {
+		t.Fatalf("ANSI returns unexpected error (%v)", err)
+	}
+	expected := "(f <k>:k\x1b[0m <s>\"s\"\x1b[0m) <c>; c\x1b[0m\n"
+	if got := b.String(); got != expected {
+		t.Errorf("ANSI: got(%q) expected (%q)", got, expected)
+	}
+}
+
+func TestHighlightError(t *testing.T) {
+	var b strings.Builder
+	err := highlight.ANSI(&b, peruse.Script("any", "(f \"s)"), highlight.Palette{"error": "<e>"})
+	if err == nil {
+		t.Errorf("ANSI expected an error for an unterminated string")
+	}
+	if got, expected := b.String(), "(f <e>\"s)\x1b[0m"; got != expected {
+		t.Errorf("ANSI: got(%q) expected (%q)", got, expected)
+	}
+}
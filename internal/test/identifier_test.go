@@ -0,0 +1,163 @@
+package test
+
+import(
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestIdentifiers(t *testing.T) {
+	const decomposed = "De\u0301f"
+	const composed = "D\u00e9f"
+	table := []struct {
+		name string
+		options []peruse.Option
+		content string
+		eat func(peruse.Text) string
+		expected string
+		remain string
+	}{
+		{
+			name: "exact",
+			content: decomposed + " x",
+			eat: func(text peruse.Text) string { return text.EatWord() },
+			expected: "",
+			remain: decomposed + " x",
+		},
+		{
+			name: "nfc word",
+			options: []peruse.Option{peruse.Identifiers(peruse.NFC, peruse.Preserve)},
+			content: decomposed + " x",
+			eat: func(text peruse.Text) string { return text.EatWord() },
+			expected: composed,
+			remain: " x",
+		},
+		{
+			name: "nfkc symbol",
+			options: []peruse.Option{peruse.Identifiers(peruse.NFKC, peruse.Preserve)},
+			content: "\ufb01le-name)",
+			eat: func(text peruse.Text) string { return text.EatSymbol() },
+			expected: "file-name",
+			remain: ")",
+		},
+		{
+			name: "nfc does not decompose ligatures",
+			options: []peruse.Option{peruse.Identifiers(peruse.NFC, peruse.Preserve)},
+			content: "\ufb01le",
+			eat: func(text peruse.Text) string { return text.EatSymbol() },
+			expected: "\ufb01le",
+		},
+		{
+			name: "upcase",
+			options: []peruse.Option{peruse.Identifiers(peruse.NoNormalization, peruse.Upcase)},
+			content: "defun x",
+			eat: func(text peruse.Text) string { return text.EatSymbol() },
+			expected: "DEFUN",
+			remain: " x",
+		},
+		{
+			name: "downcase keyword",
+			options: []peruse.Option{peruse.Identifiers(peruse.NFC, peruse.Downcase)},
+			content: ":Key" + decomposed,
+			eat: func(text peruse.Text) string { return text.EatKeyword() },
+			expected: ":key" + "d\u00e9f",
+		},
+		{
+			name: "try upcase keyword",
+			options: []peruse.Option{peruse.Identifiers(peruse.NFC, peruse.Upcase)},
+			content: ":foo x",
+			eat: func(text peruse.Text) string { keyword, _ := text.TryEatKeyword(); return keyword },
+			expected: ":FOO",
+			remain: " x",
+		},
+		{
+			name: "invert lower",
+			options: []peruse.Option{peruse.Identifiers(peruse.NoNormalization, peruse.Invert)},
+			content: "car",
+			eat: func(text peruse.Text) string { return text.EatSymbol() },
+			expected: "CAR",
+		},
+		{
+			name: "invert upper",
+			options: []peruse.Option{peruse.Identifiers(peruse.NoNormalization, peruse.Invert)},
+			content: "CAR",
+			eat: func(text peruse.Text) string { return text.EatSymbol() },
+			expected: "car",
+		},
+		{
+			name: "invert mixed",
+			options: []peruse.Option{peruse.Identifiers(peruse.NoNormalization, peruse.Invert)},
+			content: "Car",
+			eat: func(text peruse.Text) string { return text.EatSymbol() },
+			expected: "Car",
+		},
+		{
+			name: "prefixed",
+			options: []peruse.Option{peruse.Identifiers(peruse.NFC, peruse.Upcase)},
+			content: "#" + decomposed,
+			eat: func(text peruse.Text) string { _, prefixed := text.EatPrefixedWord("#"); return prefixed },
+			expected: "#D\u00c9F",
+		},
+		{
+			name: "qualified",
+			options: []peruse.Option{peruse.Identifiers(peruse.NoNormalization, peruse.Downcase)},
+			content: "NS:Name",
+			eat: func(text peruse.Text) string { first, second := text.EatSymbols(); return first + "|" + second },
+			expected: "ns|name",
+		},
+		{
+			name: "function name",
+			options: []peruse.Option{peruse.Identifiers(peruse.NFC, peruse.Upcase)},
+			content: "(defun f)",
+			eat: func(text peruse.Text) string {
+				if text.EatFunctionName("DEFUN") {
+					return "yes"
+				}
+				return "no"
+			},
+			expected: "yes",
+			remain: "f)",
+		},
+		{
+			name: "function name exact",
+			content: "(defun f)",
+			eat: func(text peruse.Text) string {
+				if text.EatFunctionName("DEFUN") {
+					return "yes"
+				}
+				return "no"
+			},
+			expected: "no",
+			remain: "(defun f)",
+		},
+	}
+	for _, row := range table {
+		text := peruse.Script("f", row.content, row.options...)
+		if got := row.eat(text); got != row.expected {
+			t.Errorf("%s: got(%q) expected (%q)", row.name, got, row.expected)
+		}
+		if got := text.Remain(); got != row.remain {
+			t.Errorf("%s: remain got(%q) expected (%q)", row.name, got, row.remain)
+		}
+	}
+}
+
+func TestIdentifiersLocation(t *testing.T) {
+	text := peruse.Script("f", "(De\u0301f :A)", peruse.Identifiers(peruse.NFC, peruse.Downcase))
+	form, err := peruse.Read(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, key := form.Forms()[0], form.Forms()[1]
+	if name.Value() != "d\u00e9f" || name.Kind() != peruse.Symbol {
+		t.Errorf("got(%s %q) expected (symbol %q)", name.Kind(), name.Value(), "d\u00e9f")
+	}
+	if got, expected := name.Span().String(), "f:1:2-1:6"; got != expected {
+		t.Errorf("got(%s) expected (%s)", got, expected)
+	}
+	if got, expected := key.Span().String(), "f:1:7-1:9"; got != expected || key.Value() != ":a" {
+		t.Errorf("got(%s %s) expected (%s :a)", got, key.Value(), expected)
+	}
+	if !text.IsSymbol("De\u0301f") {
+		t.Errorf("IsSymbol does not normalize")
+	}
+}
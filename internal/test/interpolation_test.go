@@ -0,0 +1,117 @@
+package test
+
+import(
+	"errors"
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestEatInterpolatedString(t *testing.T) {
+	table := []struct {
+		content string
+		// parts are literals or ${expression}@location
+		parts []string
+		spans []string
+		remain string
+		err string
+		reason peruse.Reason
+	}{
+		{
+			content: `"Hello ${user.name}, you have ${(count msgs)} messages" x`,
+			parts: []string{"Hello ", "${user.name}@f:1:10", ", you have ", "${(count msgs)}@f:1:33", " messages"},
+			spans: []string{"f:1:2-1:8", "f:1:8-1:20", "f:1:20-1:31", "f:1:31-1:46", "f:1:46-1:55"},
+			remain: " x",
+		},
+		{
+			content: "\"a\n  ${b}\"",
+			parts: []string{"a\n  ", "${b}@f:2:5"},
+			spans: []string{"f:1:2-2:3", "f:2:3-2:7"},
+		},
+		{
+			content: `"${(f "}" {a {b}})}${c}"`,
+			parts: []string{`${(f "}" {a {b}})}@f:1:4`, "${c}@f:1:22"},
+			spans: []string{"f:1:2-1:20", "f:1:20-1:24"},
+		},
+		{
+			content: `"\${a} \"${b}\""`,
+			parts: []string{`\${a} \"`, "${b}@f:1:12", `\"`},
+			spans: []string{"f:1:2-1:10", "f:1:10-1:14", "f:1:14-1:16"},
+		},
+		{content: `""`, parts: []string{}, spans: []string{}},
+		{content: `"a ${b"`, err: "f:1:4: unterminated interpolation", reason: peruse.Unterminated},
+		{content: `"a ${}"`, err: "f:1:4: empty part in interpolation", reason: peruse.EmptyPart},
+		{content: `"abc`, err: "f:1:1: unterminated interpolated string", reason: peruse.Unterminated},
+		{content: `x`, err: "f:1:1: expected interpolated string, found 'x'", reason: peruse.NotApplicable},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content)
+		parts, err := text.EatInterpolatedString()
+		if data.err != "" {
+			if err == nil || err.Error() != data.err || !errors.Is(err, data.reason) {
+				t.Errorf("EatInterpolatedString(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			if text.Remain() != data.content {
+				t.Errorf("EatInterpolatedString(%q) consumed content on failure", data.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("EatInterpolatedString(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		got, spans := []string{}, []string{}
+		for _, part := range parts {
+			if expression := part.Expression(); expression != nil {
+				got = append(got, "${"+expression.Remain()+"}@"+expression.Location().String())
+			} else {
+				got = append(got, part.Literal())
+			}
+			spans = append(spans, part.Span().String())
+		}
+		if strings.Join(got, "|") != strings.Join(data.parts, "|") {
+			t.Errorf("EatInterpolatedString(%q) parts: got(%q) expected (%q)", data.content, got, data.parts)
+		}
+		if strings.Join(spans, " ") != strings.Join(data.spans, " ") {
+			t.Errorf("EatInterpolatedString(%q) spans: got(%v) expected (%v)", data.content, spans, data.spans)
+		}
+		if got, expected := text.Remain(), data.remain; got != expected {
+			t.Errorf("EatInterpolatedString(%q) remain: got(%q) expected (%q)", data.content, got, expected)
+		}
+	}
+}
+
+func TestReadInterpolation(t *testing.T) {
+	table := []struct {
+		content string
+		form string
+		err string
+	}{
+		{content: `"n: ${(+ n 1)}"`, form: "(+ n 1)"},
+		{content: "\"a\n  ${(f :k\n  x)}\"", form: "(f :k x)"},
+		{content: `"ab ${) x}"`, err: "f:1:7: unexpected ')'"},
+		{content: "\"a\n  ${(f x}\"", err: "f:2:5: unclosed '('"},
+	}
+	for _, data := range table {
+		parts, err := peruse.Script("f", data.content).EatInterpolatedString()
+		if err != nil {
+			t.Errorf("EatInterpolatedString(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		expression := parts[len(parts)-1].Expression()
+		form, err := peruse.Read(expression)
+		if data.err != "" {
+			if err == nil || err.Error() != data.err {
+				t.Errorf("Read(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Read(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		if got, expected := form.String(), data.form; got != expected {
+			t.Errorf("Read(%q): got(%s) expected (%s)", data.content, got, expected)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package test
+
+import(
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestEatKey(t *testing.T) {
+	table := []struct {
+		syntax peruse.KeywordSyntax
+		content string
+		namespace string
+		name string
+		auto bool
+		remain string
+		err string
+	}{
+		{syntax: peruse.ColonKeyword, content: ":key)", name: "key", remain: ")"},
+		{syntax: peruse.ColonKeyword, content: ":my-key", err: "f:1:4: invalid character '-' in keyword"},
+		{syntax: peruse.ColonKeyword, content: "::local", err: "f:1:2: invalid character ':' in keyword"},
+		{syntax: peruse.ClojureKeywords, content: ":my-key", name: "my-key"},
+		{syntax: peruse.ClojureKeywords, content: "::local x", name: "local", auto: true, remain: " x"},
+		{syntax: peruse.ClojureKeywords, content: ":ns/key", namespace: "ns", name: "key"},
+		{syntax: peruse.ClojureKeywords, content: "::alias/my-key", namespace: "alias", name: "my-key", auto: true},
+		{syntax: peruse.ClojureKeywords, content: ":ns/", err: "f:1:5: expected keyword, found end of text"},
+		{syntax: peruse.ClojureKeywords, content: ":/key", err: "f:1:2: invalid character '/' in keyword"},
+		{syntax: peruse.ClojureKeywords, content: ":ns/a/b", err: "f:1:6: invalid character '/' in keyword"},
+		{syntax: peruse.ClojureKeywords, content: ":key-", err: "f:1:5: keyword ends with '-'"},
+		{syntax: peruse.CommonLispKeywords, content: ":ns/key", err: "f:1:4: invalid character '/' in keyword"},
+		{syntax: peruse.SmalltalkKeywords, content: "at-put: 1", name: "at-put", remain: " 1"},
+		{syntax: peruse.SmalltalkKeywords, content: ":key", err: "f:1:1: expected keyword, found ':'"},
+		{syntax: peruse.SmalltalkKeywords, content: "a:b:", err: "f:1:2: invalid character ':' in keyword"},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content, peruse.Keywords(data.syntax))
+		key, err := text.EatKey()
+		if data.err != "" {
+			if err == nil || err.Error() != data.err {
+				t.Errorf("EatKey(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			if text.EatKeyword() != "" || text.IsKeyword(data.content) {
+				t.Errorf("EatKeyword(%q) accepts an invalid keyword", data.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("EatKey(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		if key.Namespace() != data.namespace || key.Name() != data.name || key.AutoResolved() != data.auto {
+			t.Errorf("EatKey(%q) got (%s, %s, %v) expected (%s, %s, %v)", data.content,
+				key.Namespace(), key.Name(), key.AutoResolved(), data.namespace, data.name, data.auto)
+		}
+		if got, expected := key.String()+text.Remain(), data.content; got != expected {
+			t.Errorf("EatKey(%q) eats (%s) and leaves (%s)", data.content, key.String(), text.Remain())
+		}
+		if !text.IsKeyword(key.String()) {
+			t.Errorf("IsKeyword(%q) returns false", key.String())
+		}
+	}
+}
+
+func TestReadKeywords(t *testing.T) {
+	tree, err := peruse.Parse("f", "(f ::a/b-c :d)", peruse.Keywords(peruse.ClojureKeywords))
+	if err != nil {
+		t.Fatalf("Parse returns unexpected error (%v)", err)
+	}
+	for _, form := range tree.Forms()[0].Forms()[1:] {
+		if form.Kind() != peruse.Keyword {
+			t.Errorf("Parse reads (%s) as (%v) expected (%v)", form, form.Kind(), peruse.Keyword)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package test
+
+import(
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestReadDatumLabels(t *testing.T) {
+	labels := []peruse.Option{peruse.DatumLabels()}
+	table := []struct {
+		content string
+		options []peruse.Option
+		form string
+		span string
+		// same are the paths of forms that must be the same form
+		same [][]int
+		err string
+	}{
+		{content: "#0=(a b #0#)", options: labels, form: "#0=(a b #0#)", span: "f:1:1-1:13", same: [][]int{{}, {2}}},
+		{content: "(#1=(x) #1# #2=\"s\" #2#)", options: labels, form: "(#0=(x) #0# \"s\" \"s\")", same: [][]int{{0}, {1}}},
+		{content: "(#1= (x) y)", options: labels, form: "((x) y)", span: "f:1:1-1:12"},
+		{content: "#1=(a #2=(b #1#) #2#)", options: labels, form: "#0=(a #1=(b #0#) #1#)", same: [][]int{{}, {1, 1}}},
+		{content: "(#1=(x) #1#)", form: "(#1= (x) #1#)"},
+		{content: "(a #1#)", options: labels, err: "f:1:4: undefined label #1#"},
+		{content: "(#1=a\n #1=b)", options: labels, err: "f:2:2: duplicate label #1=, first defined at f:1:2"},
+		{content: "(#1=)", options: labels, err: "f:1:2: missing datum after #1="},
+		{content: "#1=#1#", options: labels, err: "f:1:4: label #1# refers to a datum that is not read yet"},
+	}
+	for _, data := range table {
+		form, err := peruse.Read(peruse.Script("f", data.content, data.options...))
+		if data.err != "" {
+			if err == nil || err.Error() != data.err {
+				t.Errorf("Read(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Read(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		if got, expected := form.String(), data.form; got != expected {
+			t.Errorf("Read(%q): got(%s) expected (%s)", data.content, got, expected)
+		}
+		if data.span != "" && form.Span().String() != data.span {
+			t.Errorf("Read(%q) span: got(%s) expected (%s)", data.content, form.Span(), data.span)
+		}
+		if len(data.same) != 0 && at(form, data.same[0]) != at(form, data.same[1]) {
+			t.Errorf("Read(%q): forms at %v and %v are not the same", data.content, data.same[0], data.same[1])
+		}
+	}
+}
+
+// at returns the form found by following path from form.
+func at(form peruse.Form, path []int) peruse.Form {
+	for _, i := range path {
+		form = form.Forms()[i]
+	}
+	return form
+}
+
+func TestParseDatumLabels(t *testing.T) {
+	// labels are local to a top-level form
+	_, err := peruse.Parse("f", "#1=(a)\n(#1#)", peruse.DatumLabels())
+	if expected := "f:2:2: undefined label #1#"; err == nil || err.Error() != expected {
+		t.Errorf("Parse error: got(%v) expected (%s)", err, expected)
+	}
+	tree, err := peruse.Parse("f", "(a)\n#0=(b #0#)", peruse.DatumLabels())
+	if err != nil {
+		t.Fatalf("Parse returns unexpected error (%v)", err)
+	}
+	edit := peruse.NewEdit(peruse.NewSpan(peruse.NewLocation("f", 1, 2), peruse.NewLocation("f", 1, 3)), "abc")
+	tree, err = peruse.Reparse(tree, edit)
+	if err != nil {
+		t.Fatalf("Reparse returns unexpected error (%v)", err)
+	}
+	forms := tree.Forms()
+	if len(forms) != 2 || forms[0].String() != "(abc)" || forms[1].String() != "#0=(b #0#)" || forms[1].Span().String() != "f:2:1-2:11" {
+		t.Errorf("Reparse: got(%v) expected ([(abc) #0=(b #0#)])", forms)
+	}
+	if forms[1].Forms()[1] != forms[1] {
+		t.Errorf("Reparse: the label of %s does not refer to it", forms[1])
+	}
+}
+
+func TestWalkDatumLabels(t *testing.T) {
+	table := []struct {
+		content string
+		visits int
+		cyclic bool
+	}{
+		{content: "#0=(a b #0#)", visits: 3, cyclic: true},
+		{content: "(#0=(x) #0#)", visits: 3},
+		{content: "(a (b #0=(c #1=(d #0#))))", visits: 8, cyclic: true},
+		{content: "(a (b c))", visits: 5},
+	}
+	for _, data := range table {
+		form, err := peruse.Read(peruse.Script("f", data.content, peruse.DatumLabels()))
+		if err != nil {
+			t.Errorf("Read(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		visits := 0
+		peruse.Walk(form, func(peruse.Form) { visits++ })
+		if visits != data.visits {
+			t.Errorf("Walk(%q): got(%d) expected (%d)", data.content, visits, data.visits)
+		}
+		if got := peruse.Cyclic(form); got != data.cyclic {
+			t.Errorf("Cyclic(%q): got(%t) expected (%t)", data.content, got, data.cyclic)
+		}
+	}
+}
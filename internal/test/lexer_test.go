@@ -0,0 +1,76 @@
+package test
+
+import(
+	"io"
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestLex(t *testing.T) {
+	text := peruse.Script("any", "(print\n \"a b\" -1 2.5 :k a.b)")
+	expected := []struct {
+		kind peruse.Kind
+		text string
+		span string
+	}{
+		{peruse.Open, "(", "any:1:1-1:2"},
+		{peruse.Symbol, "print", "any:1:2-1:7"},
+		{peruse.String, `"a b"`, "any:2:2-2:7"},
+		{peruse.Integer, "-1", "any:2:8-2:10"},
+		{peruse.Float, "2.5", "any:2:11-2:14"},
+		{peruse.Keyword, ":k", "any:2:15-2:17"},
+		{peruse.Atom, "a.b", "any:2:18-2:21"},
+		{peruse.Close, ")", "any:2:21-2:22"},
+	}
+	for _, data := range expected {
+		token, err := peruse.Lex(text)
+		if err != nil {
+			t.Fatalf("Lex returns unexpected error (%v)", err)
+		}
+		if got, expected := token.Kind(), data.kind; got != expected {
+			t.Errorf("Lex kind: got(%v) expected (%v)", got, expected)
+		}
+		if got, expected := token.Text(), data.text; got != expected {
+			t.Errorf("Lex text: got(%s) expected (%s)", got, expected)
+		}
+		if got, expected := token.Span().String(), data.span; got != expected {
+			t.Errorf("Lex span: got(%s) expected (%s)", got, expected)
+		}
+	}
+	if _, err := peruse.Lex(text); err != io.EOF {
+		t.Errorf("Lex at the end returns (%v) expected (%v)", err, io.EOF)
+	}
+}
+
+func TestLexDelimiters(t *testing.T) {
+	table := []struct {
+		content string
+		kinds string
+	}{
+		{content: "(a\tb)", kinds: "open symbol symbol close"},
+		{content: "(f(g))", kinds: "open symbol open symbol close close"},
+		{content: "(x 42\r\n)", kinds: "open symbol integer close"},
+		{content: "(x -2.5\r\n:k;c\n)", kinds: "open symbol float keyword comment close"},
+		{content: "(w\"s\")", kinds: "open symbol string close"},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content)
+		kinds := ""
+		for {
+			token, err := peruse.Lex(text)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Lex(%q) returns unexpected error (%v)", data.content, err)
+			}
+			if kinds != "" {
+				kinds += " "
+			}
+			kinds += token.Kind().String()
+		}
+		if kinds != data.kinds {
+			t.Errorf("Lex(%q): got(%s) expected (%s)", data.content, kinds, data.kinds)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package test
+
+import(
+	"errors"
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestLimits(t *testing.T) {
+	table := []struct {
+		limits peruse.Limits
+		content string
+		err string
+	}{
+		{
+			limits: peruse.Limits{Input: 10},
+			content: "(f 1 2 3 4 5)",
+			err: "f:1:1: limit exceeded: input larger than 10 bytes",
+		},
+		{
+			limits: peruse.Limits{Input: 13},
+			content: "(f 1 2 3 4 5)",
+		},
+		{
+			limits: peruse.Limits{Token: 5},
+			content: "(f abcdef)",
+			err: "f:1:4: limit exceeded: token longer than 5 bytes",
+		},
+		{
+			limits: peruse.Limits{Token: 5},
+			content: "(f abcde \"longer string\")",
+		},
+		{
+			limits: peruse.Limits{String: 3},
+			content: "(f\n  \"abcd\")",
+			err: "f:2:3: limit exceeded: string longer than 3 bytes",
+		},
+		{
+			limits: peruse.Limits{Depth: 2},
+			content: "(a (b (c)))",
+			err: "f:1:7: limit exceeded: lists nested deeper than 2",
+		},
+		{
+			limits: peruse.Limits{Depth: 2},
+			content: "(a (b) (c))",
+		},
+		{
+			limits: peruse.Limits{Forms: 4},
+			content: "(a b)\n(c d)",
+			err: "f:2:2: limit exceeded: more than 4 forms",
+		},
+		{
+			limits: peruse.Limits{Digits: 3},
+			content: "(f 123 -1.255)",
+			err: "f:1:8: limit exceeded: number with more than 3 digits",
+		},
+	}
+	for _, row := range table {
+		_, err := peruse.Parse("f", row.content, peruse.WithLimits(row.limits))
+		if row.err == "" {
+			if err != nil {
+				t.Errorf("%q: unexpected error %s", row.content, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != row.err {
+			t.Errorf("%q: got(%v) expected (%s)", row.content, err, row.err)
+			continue
+		}
+		if !errors.Is(err, peruse.LimitExceeded) {
+			t.Errorf("%q: %v is not LimitExceeded", row.content, err)
+		}
+		if _, err := peruse.ParseCST("f", row.content, peruse.WithLimits(row.limits)); err == nil || err.Error() != row.err {
+			t.Errorf("cst %q: got(%v) expected (%s)", row.content, err, row.err)
+		}
+	}
+}
+
+func TestLimitsDeepInput(t *testing.T) {
+	content := strings.Repeat("(", 100000) + strings.Repeat(")", 100000)
+	_, err := peruse.Parse("f", content, peruse.WithLimits(peruse.Limits{Depth: 64}))
+	if err == nil || err.Error() != "f:1:65: limit exceeded: lists nested deeper than 64" {
+		t.Errorf("got(%v)", err)
+	}
+	text := peruse.Script("f", "aaaaaaaa", peruse.WithLimits(peruse.Limits{Token: 4}))
+	if _, err := peruse.Lex(text); !errors.Is(err, peruse.LimitExceeded) {
+		t.Errorf("lex: got(%v) expected a limit error", err)
+	}
+}
+
+func TestLimitsRestore(t *testing.T) {
+	text := peruse.Script("f", "(a b)", peruse.WithLimits(peruse.Limits{Forms: 3}))
+	for i := 0; i < 3; i++ {
+		checkpoint := text.Checkpoint()
+		if _, err := peruse.Read(text); err != nil {
+			t.Fatalf("read %d: got(%v) expected no error", i, err)
+		}
+		text.Restore(checkpoint)
+	}
+}
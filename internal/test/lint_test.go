@@ -0,0 +1,247 @@
+package test
+
+import(
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+	"github.com/begopher/peruse/lint"
+)
+
+func TestLint(t *testing.T) {
+	table := []struct {
+		content string
+		options []peruse.Option
+		rules []lint.Rule
+		expected []string
+	}{
+		{
+			content: "(define x 1)\n(define (f) x)\n(define x 2)",
+			rules: lint.Rules(),
+			expected: []string{"f:3:9: x is already defined at f:1:9 (duplicate-define)"},
+		},
+		{
+			content: "(define (f))\n(g (lambda (x)))",
+			rules: lint.Rules(),
+			expected: []string{
+				"f:1:1: define has an empty body (empty-body)",
+				"f:2:4: lambda has an empty body (empty-body)",
+			},
+		},
+		{
+			content: "(f \"a  \n b\")  \n(g)\t",
+			rules: []lint.Rule{lint.TrailingSpace},
+			expected: []string{
+				"f:2:5: trailing space (trailing-space)",
+				"f:3:4: trailing space (trailing-space)",
+			},
+		},
+		{
+			// defines is run for duplicate-define but reports nothing
+			content: "(define x 1)  \n(define x 2)",
+			rules: []lint.Rule{lint.DuplicateDefine},
+			expected: []string{"f:2:9: x is already defined at f:1:9 (duplicate-define)"},
+		},
+		{
+			content: "(define x 1)\n(define x 2) ; peruse:ignore duplicate-define\n(define x 3) ; peruse:ignore other",
+			rules: lint.Rules(),
+			expected: []string{"f:3:9: x is already defined at f:1:9 (duplicate-define)"},
+		},
+		{
+			content: "(define x 1)\n; peruse:ignore\n(define x\n  (lambda ()))\n(define x 3)",
+			rules: lint.Rules(),
+			expected: []string{"f:5:9: x is already defined at f:1:9 (duplicate-define)"},
+		},
+		{
+			content: "(define x 1)\n(define x 2) ; peruse:ignored duplicate-define\n(define x 3) ; peruse:ignore-all duplicate-define",
+			rules: []lint.Rule{lint.DuplicateDefine},
+			expected: []string{
+				"f:2:9: x is already defined at f:1:9 (duplicate-define)",
+				"f:3:9: x is already defined at f:1:9 (duplicate-define)",
+			},
+		},
+		{
+			// '"' is a character only for the parse options
+			content: "(define x 1)\n(f '\"')\n(define x 2) ; peruse:ignore\n(g \"a\")",
+			options: []peruse.Option{peruse.Lexeme(peruse.Custom, character)},
+			rules: []lint.Rule{lint.DuplicateDefine},
+			expected: []string{},
+		},
+		{
+			// the lexer fails on '"' and goes on after it
+			content: "(define x 1)\n(f '\"')\n(define x 2) ; peruse:ignore",
+			options: []peruse.Option{peruse.Lexeme(peruse.Custom, character)},
+			rules: []lint.Rule{lint.DuplicateDefine},
+			expected: []string{},
+		},
+	}
+	for _, row := range table {
+		tree, err := peruse.Parse("f", row.content, row.options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		diagnostics, err := lint.Run(tree, row.rules...)
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", row.content, err)
+			continue
+		}
+		got := []string{}
+		for _, d := range diagnostics {
+			got = append(got, d.Error())
+		}
+		if strings.Join(got, "\n") != strings.Join(row.expected, "\n") {
+			t.Errorf("%q: got(%s) expected (%s)", row.content, strings.Join(got, "; "), strings.Join(row.expected, "; "))
+		}
+	}
+}
+
+var character = peruse.ScannerFunc(func(content string) string {
+	if len(content) >= 3 && content[0] == '\'' && content[2] == '\'' {
+		return content[:3]
+	}
+	return ""
+})
+
+func TestLintFix(t *testing.T) {
+	tree, err := peruse.Parse("f", "(f)  \n(g)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diagnostics, err := lint.Run(tree, lint.TrailingSpace)
+	if err != nil || len(diagnostics) != 1 || len(diagnostics[0].Fixes()) != 1 {
+		t.Fatalf("got(%v, %v) expected one diagnostic with a fix", diagnostics, err)
+	}
+	fixed, err := peruse.Reparse(tree, diagnostics[0].Fixes()[0].Edits()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := fixed.Content(), "(f)\n(g)"; got != expected {
+		t.Errorf("got(%q) expected (%q)", got, expected)
+	}
+}
+
+func TestLintRequires(t *testing.T) {
+	var a, b lint.Rule
+	a = lint.NewRule("a", "", func(pass lint.Pass) (any, error) {
+		pass.Report(peruse.NewLocation("f", 1, 1), "from a")
+		return 1, nil
+	})
+	b = lint.NewRule("b", "", func(pass lint.Pass) (any, error) {
+		pass.Report(peruse.NewLocation("f", 1, 2), "a returned "+strings.Repeat("x", pass.Result(a).(int)))
+		return nil, nil
+	}, a)
+	tree, _ := peruse.Parse("f", "")
+	diagnostics, err := lint.Run(tree, b)
+	if err != nil || len(diagnostics) != 1 || diagnostics[0].Error() != "f:1:2: a returned x (b)" {
+		t.Errorf("got(%v, %v) expected (f:1:2: a returned x (b))", diagnostics, err)
+	}
+	var c, d lint.Rule
+	c = lint.NewRule("c", "", nil, lint.NewRule("d", "", nil, lint.NewRule("c", "", nil)))
+	d = lint.NewRule("d", "", nil, c)
+	if _, err := lint.Run(tree, d); err == nil || !strings.Contains(err.Error(), "d -> c -> d") {
+		t.Errorf("got(%v) expected a cycle error", err)
+	}
+}
+
+func TestLintTrojanSource(t *testing.T) {
+	table := []struct {
+		content string
+		rules []lint.Rule
+		expected []string
+	}{
+		{
+			content: "(define admin \"user\u202E \u2066// check\u2069 \u2066\")\n; \u200Fcomment",
+			rules: []lint.Rule{lint.Bidi},
+			expected: []string{
+				"f:1:20: bidirectional control character U+202E (RIGHT-TO-LEFT OVERRIDE) can make the code read differently from how it runs (bidi)",
+				"f:1:22: bidirectional control character U+2066 (LEFT-TO-RIGHT ISOLATE) can make the code read differently from how it runs (bidi)",
+				"f:1:31: bidirectional control character U+2069 (POP DIRECTIONAL ISOLATE) can make the code read differently from how it runs (bidi)",
+				"f:1:33: bidirectional control character U+2066 (LEFT-TO-RIGHT ISOLATE) can make the code read differently from how it runs (bidi)",
+				"f:2:3: bidirectional control character U+200F (RIGHT-TO-LEFT MARK) can make the code read differently from how it runs (bidi)",
+			},
+		},
+		{
+			content: "\uFEFF(f \"a\u200Bb\")",
+			rules: []lint.Rule{lint.Invisible},
+			expected: []string{
+				"f:1:7: invisible character U+200B (ZERO WIDTH SPACE) hides what the code contains (invisible)",
+			},
+		},
+		{
+			content: "(define p\u0430yment 1)\n(define payment 2)\n(define \u0440\u0430\u0443 3)\n(define pay 4)",
+			rules: []lint.Rule{lint.Confusable},
+			expected: []string{
+				"f:1:9: p\u0430yment mixes Cyrillic and Latin letters, some may only look like the letters they stand for (confusable)",
+				"f:2:9: payment looks like p\u0430yment at f:1:9 but is a different name (confusable)",
+				"f:4:9: pay looks like \u0440\u0430\u0443 at f:3:9 but is a different name (confusable)",
+			},
+		},
+		{
+			content: "(define λόγος 1)\n(define данные 2)",
+			rules: []lint.Rule{lint.Confusable},
+			expected: []string{},
+		},
+	}
+	for _, row := range table {
+		tree, err := peruse.Parse("f", row.content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		diagnostics, err := lint.Run(tree, row.rules...)
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", row.content, err)
+			continue
+		}
+		got := []string{}
+		for _, d := range diagnostics {
+			got = append(got, d.Error())
+		}
+		if strings.Join(got, "\n") != strings.Join(row.expected, "\n") {
+			t.Errorf("%q: got\n%s\nexpected\n%s", row.content, strings.Join(got, "\n"), strings.Join(row.expected, "\n"))
+		}
+	}
+}
+
+func TestLintSource(t *testing.T) {
+	content := "(define p\u0430yment \"\u202E\"\n(define payment"
+	if _, err := peruse.Parse("f", content); err == nil {
+		t.Fatalf("%q: expected a parse error", content)
+	}
+	diagnostics, err := lint.Run(lint.Source("f", content), lint.Bidi, lint.Confusable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, d := range diagnostics {
+		got = append(got, d.Error())
+	}
+	expected := []string{
+		"f:1:9: p\u0430yment mixes Cyrillic and Latin letters, some may only look like the letters they stand for (confusable)",
+		"f:1:18: bidirectional control character U+202E (RIGHT-TO-LEFT OVERRIDE) can make the code read differently from how it runs (bidi)",
+		"f:2:9: payment looks like p\u0430yment at f:1:9 but is a different name (confusable)",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got(%s) expected (%s)", strings.Join(got, "; "), strings.Join(expected, "; "))
+	}
+}
+
+func TestLintCyclic(t *testing.T) {
+	tree, err := peruse.Parse("f", "#0=(lambda #0#)  \n(f \"a  \" #1=(g #1#))", peruse.DatumLabels())
+	if err != nil {
+		t.Fatal(err)
+	}
+	diagnostics, err := lint.Run(tree, lint.Rules()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, d := range diagnostics {
+		got = append(got, d.Error())
+	}
+	expected := []string{
+		"f:1:1: lambda has an empty body (empty-body)",
+		"f:1:16: trailing space (trailing-space)",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got(%s) expected (%s)", strings.Join(got, "; "), strings.Join(expected, "; "))
+	}
+}
@@ -0,0 +1,234 @@
+package test
+
+import(
+	"errors"
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestEatRawString(t *testing.T) {
+	table := []struct {
+		content string
+		value string
+		span string
+		remain string
+		err string
+		reason peruse.Reason
+	}{
+		{content: `#r"a \"b\" c"# d`, value: `a \"b\" c`, span: "f:1:1-1:15", remain: " d"},
+		{content: `##r"a "# b"## c`, value: `a "# b`, span: "f:1:1-1:14", remain: " c"},
+		{content: "#r\"select *\n  from t\"#)", value: "select *\n  from t", span: "f:1:1-2:11", remain: ")"},
+		{content: `#r""#`, value: "", span: "f:1:1-1:6"},
+		{content: `r"a"#`, err: "f:1:1: expected raw string, found 'r'", reason: peruse.NotApplicable},
+		{content: `#x"a"#`, err: "f:1:2: expected raw string, found 'x'", reason: peruse.NotApplicable},
+		{content: `##r`, err: "f:1:4: expected raw string, found end of text", reason: peruse.EndOfText},
+		{content: `##r"a"# b`, err: "f:1:1: unterminated raw string", reason: peruse.Unterminated},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content)
+		literal, err := text.EatRawString()
+		if data.err != "" {
+			if err == nil || err.Error() != data.err || !errors.Is(err, data.reason) {
+				t.Errorf("EatRawString(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			if text.Remain() != data.content {
+				t.Errorf("EatRawString(%q) consumed content on failure", data.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("EatRawString(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		if got, expected := literal.Value(), data.value; got != expected {
+			t.Errorf("EatRawString(%q) value: got(%q) expected (%q)", data.content, got, expected)
+		}
+		if got, expected := literal.Span().String(), data.span; got != expected {
+			t.Errorf("EatRawString(%q) span: got(%s) expected (%s)", data.content, got, expected)
+		}
+		if got, expected := text.Remain(), data.remain; got != expected {
+			t.Errorf("EatRawString(%q) remain: got(%q) expected (%q)", data.content, got, expected)
+		}
+	}
+}
+
+func TestEatHeredoc(t *testing.T) {
+	table := []struct {
+		content string
+		value string
+		span string
+		remain string
+		err string
+		reason peruse.Reason
+	}{
+		{
+			content: "\"\"\"\n    select *\n      from t\n    \"\"\")",
+			value: "select *\n  from t",
+			span: "f:1:1-4:8",
+			remain: ")",
+		},
+		{
+			// the closing quotes are less indented than the lines
+			content: "\"\"\"  \n    {\"a\": 1}\n\n  \"\"\"",
+			value: "  {\"a\": 1}\n",
+			span: "f:1:1-4:6",
+		},
+		{
+			// the closing quotes end the last line
+			content: "\"\"\"\n\t\ta\n\t\tb\"\"\" c",
+			value: "a\nb",
+			span: "f:1:1-3:7",
+			remain: " c",
+		},
+		{
+			// lines that end with \r\n
+			content: "\"\"\"\r\n    select *\r\n\r\n      from t\r\n    \"\"\")",
+			value: "select *\n\n  from t",
+			span: "f:1:1-5:8",
+			remain: ")",
+		},
+		{content: "\"\"\"\n\"\"\"", value: "", span: "f:1:1-2:4"},
+		{content: "\"\"\"a\n\"\"\"", err: "f:1:4: invalid character 'a' in heredoc", reason: peruse.InvalidChar},
+		{content: "\"\"\"  ", err: "f:1:6: expected heredoc, found end of text", reason: peruse.EndOfText},
+		{content: "\"\"a", err: "f:1:3: expected heredoc, found 'a'", reason: peruse.NotApplicable},
+		{content: "\"\"\"\n a\"\"", err: "f:1:1: unterminated heredoc", reason: peruse.Unterminated},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content)
+		literal, err := text.EatHeredoc()
+		if data.err != "" {
+			if err == nil || err.Error() != data.err || !errors.Is(err, data.reason) {
+				t.Errorf("EatHeredoc(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			if text.Remain() != data.content {
+				t.Errorf("EatHeredoc(%q) consumed content on failure", data.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("EatHeredoc(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		if got, expected := literal.Value(), data.value; got != expected {
+			t.Errorf("EatHeredoc(%q) value: got(%q) expected (%q)", data.content, got, expected)
+		}
+		if got, expected := literal.Span().String(), data.span; got != expected {
+			t.Errorf("EatHeredoc(%q) span: got(%s) expected (%s)", data.content, got, expected)
+		}
+		if got, expected := text.Remain(), data.remain; got != expected {
+			t.Errorf("EatHeredoc(%q) remain: got(%q) expected (%q)", data.content, got, expected)
+		}
+	}
+}
+
+func TestLiteralLocation(t *testing.T) {
+	table := []struct {
+		content string
+		heredoc bool
+		offsets []int
+		locations []string
+	}{
+		{
+			content: "(x #r\"ab\n cd\"#)",
+			offsets: []int{0, 2, 3, 4, 6},
+			locations: []string{"f:1:7", "f:1:9", "f:2:1", "f:2:2", "f:2:4"},
+		},
+		{
+			content: "\"\"\"\n    select *\n      from t\n    \"\"\"",
+			heredoc: true,
+			offsets: []int{0, 7, 9, 11, 17},
+			locations: []string{"f:2:5", "f:2:12", "f:3:5", "f:3:7", "f:3:13"},
+		},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content)
+		var literal peruse.Literal
+		var err error
+		if data.heredoc {
+			literal, err = text.EatHeredoc()
+		} else {
+			text.Eat("(x ")
+			literal, err = text.EatRawString()
+		}
+		if err != nil {
+			t.Errorf("%q returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		for i, offset := range data.offsets {
+			if got, expected := literal.Location(offset).String(), data.locations[i]; got != expected {
+				t.Errorf("%q Location(%d): got(%s) expected (%s)", data.content, offset, got, expected)
+			}
+		}
+	}
+}
+
+func TestReadLiteral(t *testing.T) {
+	table := []struct {
+		content string
+		values []string
+		err string
+	}{
+		{content: "(re #r\"\\d+ \"(\"#)", values: []string{"re", "\\d+ \"("}},
+		{content: "(sql \"\"\"\n  select 1\n  \"\"\" x)", values: []string{"sql", "select 1", "x"}},
+		{content: "(sql \"\"\" x\"\"\")", err: "f:1:10: invalid character 'x' in heredoc"},
+	}
+	for _, data := range table {
+		form, err := peruse.Read(peruse.Script("f", data.content))
+		if data.err != "" {
+			if err == nil || err.Error() != data.err {
+				t.Errorf("Read(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Read(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		if len(form.Forms()) != len(data.values) {
+			t.Errorf("Read(%q): got(%s) expected (%q)", data.content, form, data.values)
+			continue
+		}
+		for i, child := range form.Forms() {
+			if child.Value() != data.values[i] {
+				t.Errorf("Read(%q) form %d: got(%q) expected (%q)", data.content, i, child.Value(), data.values[i])
+			}
+		}
+	}
+}
+
+func TestRawStringRoundTrip(t *testing.T) {
+	table := []string{
+		"##r\"a \"# b\"##",
+		"#r\"\\\"\"#",
+		"\"\"\"\n  say \"hi\"\n  \"\"\"",
+		"(f #r\"x\"# \"y\\\"\" ###r\"\"##\"###)",
+	}
+	for _, content := range table {
+		form, err := peruse.Read(peruse.Script("f", content))
+		if err != nil {
+			t.Errorf("Read(%q) returns unexpected error (%v)", content, err)
+			continue
+		}
+		again, err := peruse.Read(peruse.Script("f", form.String()))
+		if err != nil {
+			t.Errorf("Read(%q) of %q returns unexpected error (%v)", form.String(), content, err)
+			continue
+		}
+		if got, expected := write(again), write(form); got != expected {
+			t.Errorf("%q: got(%s) expected (%s)", content, got, expected)
+		}
+	}
+}
+
+// write prints the kinds and values of form.
+func write(form peruse.Form) string {
+	if form.Kind() != peruse.List {
+		return form.Kind().String() + ":" + form.Value()
+	}
+	forms := []string{}
+	for _, child := range form.Forms() {
+		forms = append(forms, write(child))
+	}
+	return "(" + strings.Join(forms, " ") + ")"
+}
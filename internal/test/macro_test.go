@@ -0,0 +1,169 @@
+package test
+
+import(
+	"fmt"
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+	"github.com/begopher/peruse/macro"
+)
+
+const macros = `(define-syntax swap!
+  (syntax-rules ()
+    ((_ a b) (let ((tmp a)) (set! a b) (set! b tmp)))))
+(define-syntax my-or
+  (syntax-rules ()
+    ((_) false)
+    ((_ e) e)
+    ((_ e r ...) (let ((t e)) (if t t (my-or r ...))))))
+(define-syntax my-let
+  (syntax-rules ()
+    ((_ ((n v) ...) body ...) ((lambda (n ...) body ...) v ...))))
+(define-syntax flat
+  (syntax-rules ()
+    ((_ (a b ...) ...) (list a ... b ... ...))))
+(define-syntax my-if
+  (syntax-rules (then else)
+    ((_ c then t else e) (cond (c t) (else e)))))
+(define-syntax dots
+  (syntax-rules ()
+    ((_ a) (quote (a (... ...))))))
+(define-syntax loop
+  (syntax-rules ()
+    ((_ x) (begin (loop x)))))
+(define-syntax pairs
+  (syntax-rules ()
+    ((_ (a ...) (b ...)) (list (a b) ...))))
+`
+
+func expander(t *testing.T) macro.Expander {
+	forms, diagnostics := peruse.ReadRecover(peruse.Script("macros", macros), 0)
+	if len(diagnostics) != 0 {
+		t.Fatal(diagnostics)
+	}
+	expander := macro.NewExpander()
+	if _, err := expander.ExpandAll(forms); err != nil {
+		t.Fatal(err)
+	}
+	return expander
+}
+
+func TestMacro(t *testing.T) {
+	table := []struct {
+		content string
+		expected string
+		err string
+	}{
+		{
+			content: "(swap! tmp other)",
+			expected: "(let ((tmp.1 tmp)) (set! tmp other) (set! other tmp.1))",
+		},
+		{
+			content: "(my-or)",
+			expected: "false",
+		},
+		{
+			content: "(my-or x y z)",
+			expected: "(let ((t.1 x)) (if t.1 t.1 (let ((t.2 y)) (if t.2 t.2 z))))",
+		},
+		{
+			content: "(my-let ((a 1) (b 2)) (add a b) b)",
+			expected: "((lambda (a b) (add a b) b) 1 2)",
+		},
+		{
+			content: "(flat (1 2 3) (4) (5 6))",
+			expected: "(list 1 4 5 2 3 6)",
+		},
+		{
+			content: "(my-if x then 1 else 2)",
+			expected: "(cond (x 1) (else 2))",
+		},
+		{
+			content: "(my-if x 1 else 2)",
+			err: "f:1:1: no rule of my-if matches",
+		},
+		{
+			content: "(dots z)",
+			expected: "(quote (z ...))",
+		},
+		{
+			content: "(quote (swap! a b))",
+			expected: "(quote (swap! a b))",
+		},
+		{
+			content: "(loop 1)",
+			err: "macros:23:19: expansion of loop is too deep (expanded from macros:23:19, f:1:1)",
+		},
+		{
+			content: "(pairs (1 2) (3))",
+			err: "macros:26:32: a and b repeat a different number of times",
+		},
+	}
+	for _, row := range table {
+		form, err := peruse.Read(peruse.Script("f", row.content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := expander(t).Expand(form)
+		if row.err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), row.err) {
+				t.Errorf("%q: got(%v) expected (%s)", row.content, err, row.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", row.content, err)
+			continue
+		}
+		if got := result.String(); got != row.expected {
+			t.Errorf("got(%s) expected (%s)", got, row.expected)
+		}
+	}
+}
+
+func TestMacroLocations(t *testing.T) {
+	text := peruse.Script("f", "(define (f)\n  (my-or a (swap! b c)))")
+	form, err := peruse.Read(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := expander(t).Expand(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// (define (f) (let ((t.1 a)) (if t.1 t.1 (let ((tmp.2 b)) ...))))
+	let := result.Forms()[2]
+	if got, expected := let.Span().String(), "macros:8:18-8:54"; got != expected {
+		t.Errorf("got(%s) expected (%s)", got, expected)
+	}
+	a := let.Forms()[1].Forms()[0].Forms()[1]
+	if got, expected := a.Span().String(), "f:2:10-2:11"; got != expected {
+		t.Errorf("got(%s) expected (%s)", got, expected)
+	}
+	set := let.Forms()[2].Forms()[3].Forms()[2]
+	if got, expected := set.String(), "(set! b c)"; got != expected {
+		t.Errorf("got(%s) expected (%s)", got, expected)
+	}
+	chain := []string{}
+	for _, use := range macro.Chain(set) {
+		chain = append(chain, use.Span().Start().String())
+	}
+	if got, expected := strings.Join(chain, " "), "f:2:12 macros:8:39 f:2:3"; got != expected {
+		t.Errorf("chain: got(%s) expected (%s)", got, expected)
+	}
+	err = macro.Diagnostic(set, "set! of an undefined variable")
+	if got, expected := err.Error(), "macros:3:29: set! of an undefined variable (expanded from f:2:12, macros:8:39, f:2:3)"; got != expected {
+		t.Errorf("got(%s) expected (%s)", got, expected)
+	}
+}
+
+func TestMacroCyclic(t *testing.T) {
+	form, err := peruse.Read(peruse.Script("f", "(swap! a #0=(b #0#))", peruse.DatumLabels()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = expander(t).Expand(form)
+	if got, expected := fmt.Sprint(err), "f:1:1: form contains itself and cannot be expanded"; got != expected {
+		t.Errorf("got(%s) expected (%s)", got, expected)
+	}
+}
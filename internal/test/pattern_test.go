@@ -0,0 +1,168 @@
+package test
+
+import(
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+	"github.com/begopher/peruse/pattern"
+)
+
+func TestPattern(t *testing.T) {
+	define := pattern.MustCompile("(define <name:symbol> <body:expr>...)")
+	let := pattern.MustCompile("(let ((<symbol> <expr>)*) <body>+)")
+	table := []struct {
+		pattern pattern.Pattern
+		content string
+		// captures are written as name=value@span
+		captures []string
+		err string
+	}{
+		{
+			pattern: define,
+			content: "(define x 1)",
+			captures: []string{"name=x@f:1:9-1:10", "body=1@f:1:11-1:12"},
+		},
+		{
+			pattern: define,
+			content: "(define x)",
+			captures: []string{"name=x@f:1:9-1:10"},
+		},
+		{
+			pattern: define,
+			content: "(define\n\n\n        1 2)",
+			err: "f:4:9: define expects a name",
+		},
+		{
+			pattern: define,
+			content: "(define)",
+			err: "f:1:8: define expects a name",
+		},
+		{
+			pattern: define,
+			content: "(defun x 1)",
+			err: "f:1:1: expected (define ...)",
+		},
+		{
+			pattern: let,
+			content: "(let ((a 1) (b (f x))) a b)",
+			captures: []string{
+				"symbol=a@f:1:8-1:9", "symbol=b@f:1:14-1:15",
+				"expr=1@f:1:10-1:11", "expr=(f x)@f:1:16-1:21",
+				"body=a@f:1:24-1:25", "body=b@f:1:26-1:27",
+			},
+		},
+		{
+			pattern: let,
+			content: "(let () x)",
+			captures: []string{"body=x@f:1:9-1:10"},
+		},
+		{
+			pattern: let,
+			content: "(let ((a 1)))",
+			err: "f:1:13: let expects a body",
+		},
+		{
+			pattern: let,
+			content: "(let ((1 a)) a)",
+			err: "f:1:8: let expects a symbol",
+		},
+		{
+			pattern: let,
+			content: "(let ((a 1 2)) a)",
+			err: "f:1:12: let expects ')'",
+		},
+		{
+			pattern: let,
+			content: "(let (a) a)",
+			err: "f:1:7: let expects a list",
+		},
+		{
+			pattern: pattern.MustCompile("(if <test> <then> else <else>)"),
+			content: "(if a b otherwise c)",
+			err: "f:1:9: if expects else",
+		},
+		{
+			// heads that are not read as symbols
+			pattern: pattern.MustCompile("(+ <expr> <expr>)"),
+			content: "(+ 1 (f x))",
+			captures: []string{"expr=1@f:1:4-1:5", "expr=(f x)@f:1:6-1:11"},
+		},
+		{
+			pattern: pattern.MustCompile("(set! <name:symbol> <expr>)"),
+			content: "(set! x 2)",
+			captures: []string{"name=x@f:1:7-1:8", "expr=2@f:1:9-1:10"},
+		},
+		{
+			// symbols that end like a repetition are literals
+			pattern: pattern.MustCompile("(let* ((<symbol> <expr>)*) <body>...)"),
+			content: "(let* ((a 1) (b a)) b)",
+			captures: []string{"symbol=a@f:1:9-1:10", "symbol=b@f:1:15-1:16", "expr=1@f:1:11-1:12", "expr=a@f:1:17-1:18", "body=b@f:1:21-1:22"},
+		},
+		{
+			pattern: pattern.MustCompile("(1+ <expr>)"),
+			content: "(1+ n)",
+			captures: []string{"expr=n@f:1:5-1:6"},
+		},
+		{
+			pattern: pattern.MustCompile("(vector-set! <name:symbol> <expr>+)"),
+			content: "(vector-set! v 1 2)",
+			captures: []string{"name=v@f:1:14-1:15", "expr=1@f:1:16-1:17", "expr=2@f:1:18-1:19"},
+		},
+		{
+			pattern: pattern.MustCompile("(lambda* (<symbol>...) <body>+)"),
+			content: "(lambda (x) x)",
+			err: "f:1:1: expected (lambda* ...)",
+		},
+		{
+			pattern: pattern.MustCompile("(+ <expr> <expr>)"),
+			content: "(- 1 2)",
+			err: "f:1:1: expected (+ ...)",
+		},
+	}
+	for _, row := range table {
+		text := peruse.Script("f", row.content+" rest")
+		m, err := row.pattern.Match(text)
+		if row.err != "" {
+			if err == nil || err.Error() != row.err {
+				t.Errorf("%q: got(%v) expected (%s)", row.content, err, row.err)
+			}
+			if text.Remain() != row.content+" rest" {
+				t.Errorf("%q: text was eaten on failure", row.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", row.content, err)
+			continue
+		}
+		captures := []string{}
+		for _, name := range []string{"name", "symbol", "expr", "body"} {
+			for _, form := range m.Captures(name) {
+				captures = append(captures, name+"="+form.String()+"@"+form.Span().String())
+			}
+		}
+		if got, expected := strings.Join(captures, " "), strings.Join(row.captures, " "); got != expected {
+			t.Errorf("%q: got(%s) expected (%s)", row.content, got, expected)
+		}
+		if text.Remain() != " rest" {
+			t.Errorf("%q: remain got(%q) expected (%q)", row.content, text.Remain(), " rest")
+		}
+	}
+}
+
+func TestPatternCompile(t *testing.T) {
+	table := []struct {
+		source string
+		err string
+	}{
+		{"(f <a>... <b>...)", "pattern:1:11: a list has at most one repeated element"},
+		{"(...)", "pattern:1:2: ... repeats nothing"},
+		{"(f <a:number>)", "pattern:1:4: invalid capture <a:number>"},
+		{"f", "pattern:1:1: pattern must be a list"},
+	}
+	for _, row := range table {
+		if _, err := pattern.Compile(row.source); err == nil || err.Error() != row.err {
+			t.Errorf("%q: got(%v) expected (%s)", row.source, err, row.err)
+		}
+	}
+}
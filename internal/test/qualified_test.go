@@ -0,0 +1,84 @@
+package test
+
+import(
+	"errors"
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestEatQualifiedSymbol(t *testing.T) {
+	table := []struct {
+		content string
+		separators []string
+		segments []string
+		spans []string
+		remain string
+		err string
+		reason peruse.Reason
+	}{
+		{
+			content: "clojure.core/map-indexed x",
+			separators: []string{"/", "."},
+			segments: []string{"clojure", ".core", "/map-indexed"},
+			spans: []string{"f:1:1-1:8", "f:1:9-1:13", "f:1:14-1:25"},
+			remain: " x",
+		},
+		{
+			content: "pkg::sym)",
+			separators: []string{":", "::"},
+			segments: []string{"pkg", "::sym"},
+			spans: []string{"f:1:1-1:4", "f:1:6-1:9"},
+			remain: ")",
+		},
+		{
+			content: "a:b:c",
+			separators: []string{":"},
+			segments: []string{"a", ":b", ":c"},
+			spans: []string{"f:1:1-1:2", "f:1:3-1:4", "f:1:5-1:6"},
+		},
+		{
+			content: "name",
+			separators: []string{"/"},
+			segments: []string{"name"},
+			spans: []string{"f:1:1-1:5"},
+		},
+		{content: "ns//name", separators: []string{"/"}, err: "f:1:4: empty part in qualified symbol", reason: peruse.EmptyPart},
+		{content: "ns/", separators: []string{"/"}, err: "f:1:4: empty part in qualified symbol", reason: peruse.EmptyPart},
+		{content: "pkg.sub-.fn", separators: []string{"."}, err: "f:1:8: qualified symbol ends with '-'", reason: peruse.TrailingHyphen},
+		{content: "pkg.1sub", separators: []string{"."}, err: "f:1:5: invalid character '1' in qualified symbol", reason: peruse.InvalidChar},
+		{content: "a.b_c", separators: []string{"."}, err: "f:1:4: invalid character '_' in qualified symbol", reason: peruse.InvalidChar},
+		{content: " a", separators: []string{"."}, err: "f:1:1: expected qualified symbol, found ' '", reason: peruse.NotApplicable},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content)
+		segments, err := text.EatQualifiedSymbol(data.separators...)
+		if data.err != "" {
+			if err == nil || err.Error() != data.err || !errors.Is(err, data.reason) {
+				t.Errorf("EatQualifiedSymbol(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			if text.Remain() != data.content {
+				t.Errorf("EatQualifiedSymbol(%q) consumed content on failure", data.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("EatQualifiedSymbol(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		got, spans := []string{}, []string{}
+		for _, segment := range segments {
+			got = append(got, segment.Separator()+segment.Value())
+			spans = append(spans, segment.Span().String())
+		}
+		if strings.Join(got, " ") != strings.Join(data.segments, " ") {
+			t.Errorf("EatQualifiedSymbol(%q) segments: got(%v) expected (%v)", data.content, got, data.segments)
+		}
+		if strings.Join(spans, " ") != strings.Join(data.spans, " ") {
+			t.Errorf("EatQualifiedSymbol(%q) spans: got(%v) expected (%v)", data.content, spans, data.spans)
+		}
+		if got, expected := text.Remain(), data.remain; got != expected {
+			t.Errorf("EatQualifiedSymbol(%q) remain: got(%q) expected (%q)", data.content, got, expected)
+		}
+	}
+}
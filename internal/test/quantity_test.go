@@ -0,0 +1,112 @@
+package test
+
+import(
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"github.com/begopher/peruse"
+)
+
+func TestEatQuantity(t *testing.T) {
+	units := peruse.WithUnits(peruse.DurationUnits(), peruse.ByteUnits(), peruse.PercentUnits())
+	table := []struct {
+		content string
+		options []peruse.Option
+		number string
+		unit string
+		value any
+		span string
+		remain string
+		err string
+		reason peruse.Reason
+	}{
+		{content: "30s x", options: []peruse.Option{units}, number: "30", unit: "s", value: 30 * time.Second, span: "f:1:1-1:4", remain: " x"},
+		{content: "1.5h)", options: []peruse.Option{units}, number: "1.5", unit: "h", value: 90 * time.Minute, span: "f:1:1-1:5", remain: ")"},
+		{content: "512MiB", options: []peruse.Option{units}, number: "512", unit: "MiB", value: int64(512 << 20), span: "f:1:1-1:7"},
+		{content: "1.5KB", options: []peruse.Option{units}, number: "1.5", unit: "KB", value: int64(1500), span: "f:1:1-1:6"},
+		{content: "1.001KB", options: []peruse.Option{units}, number: "1.001", unit: "KB", value: int64(1001), span: "f:1:1-1:8"},
+		{content: "1.003KB", options: []peruse.Option{units}, number: "1.003", unit: "KB", value: int64(1003), span: "f:1:1-1:8"},
+		{content: "1.005KB", options: []peruse.Option{units}, number: "1.005", unit: "KB", value: int64(1005), span: "f:1:1-1:8"},
+		{content: "1.007KB", options: []peruse.Option{units}, number: "1.007", unit: "KB", value: int64(1007), span: "f:1:1-1:8"},
+		{content: "1.009KB", options: []peruse.Option{units}, number: "1.009", unit: "KB", value: int64(1009), span: "f:1:1-1:8"},
+		{content: "8388608TiB", options: []peruse.Option{units}, err: "f:1:1: invalid quantity 8388608TiB: too many bytes"},
+		{content: "90%", options: []peruse.Option{units}, number: "90", unit: "%", value: 0.9, span: "f:1:1-1:4"},
+		{content: "-2ms", options: []peruse.Option{units}, number: "-2", unit: "ms", value: -2 * time.Millisecond, span: "f:1:1-1:5"},
+		{content: "30sec", options: []peruse.Option{units}, err: `f:1:3: unknown unit "sec"`, reason: peruse.UnknownUnit},
+		{content: "0.5B", options: []peruse.Option{units}, err: "f:1:1: invalid quantity 0.5B: fraction of a byte"},
+		{content: "-1KB", options: []peruse.Option{units}, err: "f:1:1: invalid quantity -1KB: negative size"},
+		{content: "1+ x", options: []peruse.Option{units}, err: "f:1:2: expected quantity, found '+'", reason: peruse.NotApplicable},
+		{content: "30 s", options: []peruse.Option{units}, err: "f:1:3: expected quantity, found ' '", reason: peruse.NotApplicable},
+		{content: "s30", options: []peruse.Option{units}, err: "f:1:1: expected quantity, found 's'", reason: peruse.NotApplicable},
+		{content: "30s", err: "f:1:1: expected quantity, found '3'", reason: peruse.NotApplicable},
+		{content: "", options: []peruse.Option{units}, err: "f:1:1: expected quantity, found end of text", reason: peruse.EndOfText},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content, data.options...)
+		quantity, err := text.EatQuantity()
+		if data.err != "" {
+			if err == nil || err.Error() != data.err || (data.reason != 0 && !errors.Is(err, data.reason)) {
+				t.Errorf("EatQuantity(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			if text.Remain() != data.content {
+				t.Errorf("EatQuantity(%q) consumed content on failure", data.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("EatQuantity(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		if quantity.Number() != data.number || quantity.Unit() != data.unit {
+			t.Errorf("EatQuantity(%q): got(%s %s) expected (%s %s)", data.content, quantity.Number(), quantity.Unit(), data.number, data.unit)
+		}
+		if got, expected := fmt.Sprintf("%T %v", quantity.Value(), quantity.Value()), fmt.Sprintf("%T %v", data.value, data.value); got != expected {
+			t.Errorf("EatQuantity(%q) value: got(%s) expected (%s)", data.content, got, expected)
+		}
+		if got, expected := quantity.Span().String(), data.span; got != expected {
+			t.Errorf("EatQuantity(%q) span: got(%s) expected (%s)", data.content, got, expected)
+		}
+		if got, expected := text.Remain(), data.remain; got != expected {
+			t.Errorf("EatQuantity(%q) remain: got(%q) expected (%q)", data.content, got, expected)
+		}
+	}
+}
+
+func TestReadQuantity(t *testing.T) {
+	units := peruse.WithUnits(peruse.DurationUnits(), peruse.Units{"x": func(number string) (any, error) { return number, nil }})
+	table := []struct {
+		content string
+		options []peruse.Option
+		kinds string
+		err string
+	}{
+		{content: "(timeout 30s 2x 3 1.5)", options: []peruse.Option{units}, kinds: "symbol measure measure integer float"},
+		{content: "(timeout 30s)", kinds: "symbol atom"},
+		{content: "(1+ 2.5-x)", options: []peruse.Option{units}, kinds: "atom atom"},
+		{content: "(timeout\n  30y)", options: []peruse.Option{units}, err: `f:2:5: unknown unit "y"`},
+	}
+	for _, data := range table {
+		form, err := peruse.Read(peruse.Script("f", data.content, data.options...))
+		if data.err != "" {
+			if err == nil || err.Error() != data.err {
+				t.Errorf("Read(%q) error: got(%v) expected (%s)", data.content, err, data.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Read(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		kinds := ""
+		for i, child := range form.Forms() {
+			if i != 0 {
+				kinds += " "
+			}
+			kinds += child.Kind().String()
+		}
+		if kinds != data.kinds {
+			t.Errorf("Read(%q) kinds: got(%s) expected (%s)", data.content, kinds, data.kinds)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package test
+
+import(
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestSkip(t *testing.T) {
+	table := []struct {
+		content string
+		skip func(peruse.Text) string
+		skipped string
+		remain string
+		column int
+		line int
+	}{
+		{content: "a_b c", skip: peruse.Text.SkipToDelimiter, skipped: "a_b", remain: " c", column: 4, line: 1},
+		{content: "a.b)", skip: peruse.Text.SkipToDelimiter, skipped: "a.b", remain: ")", column: 4, line: 1},
+		{content: ") c", skip: peruse.Text.SkipToDelimiter, skipped: ")", remain: " c", column: 2, line: 1},
+		{content: "abc", skip: peruse.Text.SkipToDelimiter, skipped: "abc", remain: "", column: 4, line: 1},
+		{content: "(a (b \")\")\n c) d", skip: peruse.Text.SkipBalancedForm, skipped: "(a (b \")\")\n c)", remain: " d", column: 4, line: 2},
+		{content: "(a (b)", skip: peruse.Text.SkipBalancedForm, skipped: "(a (b)", remain: "", column: 7, line: 1},
+		{content: "\"a\\\"\" b", skip: peruse.Text.SkipBalancedForm, skipped: "\"a\\\"\"", remain: " b", column: 6, line: 1},
+		{content: "(a ; )\n b) rest", skip: peruse.Text.SkipBalancedForm, skipped: "(a ; )\n b)", remain: " rest", column: 4, line: 2},
+		{content: "(a ##r\"x)\"## b) c", skip: peruse.Text.SkipBalancedForm, skipped: "(a ##r\"x)\"## b)", remain: " c", column: 16, line: 1},
+		{content: "(f \"\"\"\n  )\n  \"\"\") g", skip: peruse.Text.SkipBalancedForm, skipped: "(f \"\"\"\n  )\n  \"\"\")", remain: " g", column: 7, line: 3},
+		{content: "#r\"a b\"# c", skip: peruse.Text.SkipBalancedForm, skipped: "#r\"a b\"#", remain: " c", column: 9, line: 1},
+		{content: "a.b (c)", skip: peruse.Text.SkipBalancedForm, skipped: "a.b", remain: " (c)", column: 4, line: 1},
+		{content: "(a b\n (c)\n(d)", skip: peruse.Text.SkipToNextTopLevel, skipped: "(a b\n (c)\n", remain: "(d)", column: 1, line: 3},
+		{content: "(a b) (c)", skip: peruse.Text.SkipToNextTopLevel, skipped: "(a b) (c)", remain: "", column: 10, line: 1},
+	}
+	for _, data := range table {
+		text := peruse.Script("any", data.content)
+		if got, expected := data.skip(text), data.skipped; got != expected {
+			t.Errorf("Skip(%q) skipped: got(%q) expected (%q)", data.content, got, expected)
+		}
+		if got, expected := text.Remain(), data.remain; got != expected {
+			t.Errorf("Skip(%q) remain: got(%q) expected (%q)", data.content, got, expected)
+		}
+		if got, expected := text.Column(), data.column; got != expected {
+			t.Errorf("Skip(%q) column: got(%d) expected (%d)", data.content, got, expected)
+		}
+		if got, expected := text.Line(), data.line; got != expected {
+			t.Errorf("Skip(%q) line: got(%d) expected (%d)", data.content, got, expected)
+		}
+	}
+}
+
+func TestReadRecover(t *testing.T) {
+	content := "(define a (f 1)\n(define b 2)\n(print \"x)\n(ok)\n) (fine)\n(last"
+	table := []struct {
+		limit int
+		forms []string
+		diagnostics []string
+	}{
+		{
+			limit: 0,
+			forms: []string{"(define b 2)", "(ok)", "(fine)"},
+			diagnostics: []string{
+				"any:1:1: unclosed '('",
+				"any:3:8: unterminated string",
+				"any:5:1: unexpected ')'",
+				"any:6:1: unclosed '('",
+			},
+		},
+		{
+			limit: 2,
+			forms: []string{"(define b 2)"},
+			diagnostics: []string{"any:1:1: unclosed '('", "any:3:8: unterminated string"},
+		},
+	}
+	for _, data := range table {
+		forms, diagnostics := peruse.ReadRecover(peruse.Script("any", content), data.limit)
+		if got, expected := len(forms), len(data.forms); got != expected {
+			t.Fatalf("ReadRecover(%d) returns (%d) forms expected (%d)", data.limit, got, expected)
+		}
+		for i, form := range forms {
+			if got, expected := form.String(), data.forms[i]; got != expected {
+				t.Errorf("ReadRecover(%d) form: got(%s) expected (%s)", data.limit, got, expected)
+			}
+		}
+		if got, expected := len(diagnostics), len(data.diagnostics); got != expected {
+			t.Fatalf("ReadRecover(%d) returns (%d) diagnostics expected (%d)", data.limit, got, expected)
+		}
+		for i, diagnostic := range diagnostics {
+			if got, expected := diagnostic.Error(), data.diagnostics[i]; got != expected {
+				t.Errorf("ReadRecover(%d) diagnostic: got(%s) expected (%s)", data.limit, got, expected)
+			}
+		}
+	}
+}
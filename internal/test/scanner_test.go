@@ -0,0 +1,86 @@
+package test
+
+import(
+	"strings"
+	"testing"
+	"unicode"
+	"github.com/begopher/peruse"
+)
+
+const Duration = peruse.Custom
+
+var duration = peruse.ScannerFunc(func(content string) string {
+	digits := strings.IndexFunc(content, func(r rune) bool { return !unicode.IsDigit(r) })
+	if digits <= 0 {
+		return ""
+	}
+	for _, unit := range []string{"ms", "s", "h"} {
+		if strings.HasPrefix(content[digits:], unit) {
+			return content[:digits+len(unit)]
+		}
+	}
+	return ""
+})
+
+var block = peruse.ScannerFunc(func(content string) string {
+	if !strings.HasPrefix(content, "#|") {
+		return ""
+	}
+	if end := strings.Index(content, "|#"); end > 0 {
+		return content[:end+2]
+	}
+	return ""
+})
+
+func TestEatWith(t *testing.T) {
+	table := []struct {
+		content string
+		scanner peruse.Scanner
+		expected string
+		remain string
+		column int
+		line int
+	}{
+		{content: "10ms later", scanner: duration, expected: "10ms", remain: " later", column: 5, line: 1},
+		{content: "10 later", scanner: duration, expected: "", remain: "10 later", column: 1, line: 1},
+		{content: "#| a\nbc |# d", scanner: block, expected: "#| a\nbc |#", remain: " d", column: 6, line: 2},
+		{content: "+12 a", scanner: peruse.IntegerScanner(), expected: "+12", remain: " a", column: 4, line: 1},
+		{content: "1.5 a", scanner: peruse.Scanners(peruse.IntegerScanner(), peruse.FloatScanner()), expected: "1.5", remain: " a", column: 4, line: 1},
+		{content: "5s", scanner: peruse.Scanners(peruse.IntegerScanner(), duration), expected: "5s", remain: "", column: 3, line: 1},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content)
+		if got, expected := text.EatWith(data.scanner), data.expected; got != expected {
+			t.Errorf("EatWith(%q): got(%q) expected (%q)", data.content, got, expected)
+		}
+		if got, expected := text.Remain(), data.remain; got != expected {
+			t.Errorf("EatWith(%q) remain: got(%q) expected (%q)", data.content, got, expected)
+		}
+		if got, expected := text.Column(), data.column; got != expected {
+			t.Errorf("EatWith(%q) column: got(%d) expected (%d)", data.content, got, expected)
+		}
+		if got, expected := text.Line(), data.line; got != expected {
+			t.Errorf("EatWith(%q) line: got(%d) expected (%d)", data.content, got, expected)
+		}
+	}
+}
+
+func TestLexemeOption(t *testing.T) {
+	tree, err := peruse.Parse("f", "(sleep 10ms 5)", peruse.Lexeme(Duration, duration))
+	if err != nil {
+		t.Fatalf("Parse returns unexpected error (%v)", err)
+	}
+	forms := tree.Forms()[0].Forms()
+	if got, expected := forms[1].Kind(), Duration; got != expected {
+		t.Errorf("Parse reads (%s) as (%v) expected (%v)", forms[1], got, expected)
+	}
+	if got, expected := forms[1].Span().String(), "f:1:8-1:12"; got != expected {
+		t.Errorf("Parse span of (%s): got(%s) expected (%s)", forms[1], got, expected)
+	}
+	if got, expected := forms[2].Kind(), peruse.Integer; got != expected {
+		t.Errorf("Parse reads (%s) as (%v) expected (%v)", forms[2], got, expected)
+	}
+	if got, expected := Duration.String(), "custom+0"; got != expected {
+		t.Errorf("Kind.String: got(%s) expected (%s)", got, expected)
+	}
+}
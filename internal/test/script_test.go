@@ -1,6 +1,7 @@
 package test
 
 import(
+	"fmt"
 	"testing"
 	"github.com/begopher/peruse"
 )
@@ -23,6 +24,7 @@ func TestLength(t *testing.T) {
 		{"a", 1},
 		{"ab", 2},
 		{"any", 3},
+		{"مرحبا", 5},
 	}
 
 	for _, data := range table {
@@ -142,7 +144,8 @@ func TestString(t *testing.T) {
 			column: 8,
 			line: 2,
 		},
-		
+		{content: `"any\`, expected: "", remain: `"any\`, column: 1, line: 1},
+		{content: `"قيمة" any`, expected: "قيمة", remain: " any", column: 7, line: 1},
 	}
 
 	for _, data := range table {
@@ -585,6 +588,7 @@ func TestKeyword(t *testing.T) {
 		{content: ":1234", expected: "", remain:":1234", col: 1, line: 1},
 		{content: ":A", expected: ":A", remain:"", col: 3, line: 1},
 		{content: ":any n", expected: ":any", remain:" n", col: 5, line: 1},
+		{content: ":any)", expected: ":any", remain:")", col: 5, line: 1},
 		{content: ":a-ny", expected: "", remain:":a-ny", col: 1, line: 1},
 		{content: ":a_ny", expected: "", remain:":a_ny", col: 1, line: 1},
 		{content: ":-any", expected: "", remain:":-any", col: 1, line: 1},
@@ -802,3 +806,40 @@ func TestBeginWith(t *testing.T) {
 	}
 
 }
+
+// TestDelimiters checks that tokens end at every delimiter of Lex,
+// before they ended only at a space, ')' and '\n' so that "abc\tdef"
+// was not a word at all.
+func TestDelimiters(t *testing.T) {
+	table := []struct {
+		content string
+		eat func(peruse.Text) string
+		expected string
+		remain string
+	}{
+		{"abc\tdef", func(text peruse.Text) string { return text.EatWord() }, "abc", "\tdef"},
+		{"abc(d)", func(text peruse.Text) string { return text.EatWord() }, "abc", "(d)"},
+		{"sym;c", func(text peruse.Text) string { return text.EatSymbol() }, "sym", ";c"},
+		{":k\"s\"", func(text peruse.Text) string { return text.EatKeyword() }, ":k", "\"s\""},
+		{"42\r\n", func(text peruse.Text) string { return text.EatInteger() }, "42", "\r\n"},
+		{"-1.5;c", func(text peruse.Text) string { return text.EatFloat() }, "-1.5", ";c"},
+		{"\"abc", func(text peruse.Text) string { return text.SkipToDelimiter() }, "\"", "abc"},
+		{"abc;c", func(text peruse.Text) string { return text.SkipToDelimiter() }, "abc", ";c"},
+		{"(f\tx)", func(text peruse.Text) string { return fmt.Sprint(text.EatFunctionName("f")) }, "true", "x)"},
+		{"(f(x))", func(text peruse.Text) string { return fmt.Sprint(text.EatFunctionName("f")) }, "true", "(x))"},
+		{"(fx)", func(text peruse.Text) string { return fmt.Sprint(text.EatFunctionName("f")) }, "false", "(fx)"},
+		{"a/b\tc", func(text peruse.Text) string {
+			segments, _ := text.EatQualifiedSymbol("/")
+			return fmt.Sprint(len(segments))
+		}, "2", "\tc"},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content)
+		if got := data.eat(text); got != data.expected {
+			t.Errorf("%q: got(%q) expected (%q)", data.content, got, data.expected)
+		}
+		if got := text.Remain(); got != data.remain {
+			t.Errorf("%q remain: got(%q) expected (%q)", data.content, got, data.remain)
+		}
+	}
+}
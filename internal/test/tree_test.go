@@ -0,0 +1,228 @@
+package test
+
+import(
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestRead(t *testing.T) {
+	table := []struct {
+		content string
+		kind peruse.Kind
+		printed string
+		span string
+	}{
+		{content: "name", kind: peruse.Symbol, printed: "name", span: "any:1:1-1:5"},
+		{content: "  my-name)", kind: peruse.Symbol, printed: "my-name", span: "any:1:3-1:10"},
+		{content: ":key", kind: peruse.Keyword, printed: ":key", span: "any:1:1-1:5"},
+		{content: "-12", kind: peruse.Integer, printed: "-12", span: "any:1:1-1:4"},
+		{content: "1.5", kind: peruse.Float, printed: "1.5", span: "any:1:1-1:4"},
+		{content: `"a b"`, kind: peruse.String, printed: `"a b"`, span: "any:1:1-1:6"},
+		{content: "+", kind: peruse.Atom, printed: "+", span: "any:1:1-1:2"},
+		{content: "a.b(", kind: peruse.Atom, printed: "a.b", span: "any:1:1-1:4"},
+		{content: "()", kind: peruse.List, printed: "()", span: "any:1:1-1:3"},
+		{
+			content: "(define\n  (add a b) (+ a 1.5 :x \"s\"))",
+			kind: peruse.List,
+			printed: `(define (add a b) (+ a 1.5 :x "s"))`,
+			span: "any:1:1-2:30",
+		},
+	}
+	for _, data := range table {
+		form, err := peruse.Read(peruse.Script("any", data.content))
+		if err != nil {
+			t.Errorf("Read(%q) returns unexpected error (%v)", data.content, err)
+			continue
+		}
+		if got, expected := form.Kind(), data.kind; got != expected {
+			t.Errorf("Read(%q) kind: got(%v) expected (%v)", data.content, got, expected)
+		}
+		if got, expected := form.String(), data.printed; got != expected {
+			t.Errorf("Read(%q) form: got(%s) expected (%s)", data.content, got, expected)
+		}
+		if got, expected := form.Span().String(), data.span; got != expected {
+			t.Errorf("Read(%q) span: got(%s) expected (%s)", data.content, got, expected)
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	table := []struct {
+		content string
+		err string
+	}{
+		{content: ")", err: "any:1:1: unexpected ')'"},
+		{content: "(a\n (b)", err: "any:1:1: unclosed '('"},
+		{content: `(a "b)`, err: "any:1:4: unterminated string"},
+	}
+	for _, data := range table {
+		_, err := peruse.Read(peruse.Script("any", data.content))
+		if err == nil {
+			t.Errorf("Read(%q) expected error (%s)", data.content, data.err)
+			continue
+		}
+		if got, expected := err.Error(), data.err; got != expected {
+			t.Errorf("Read(%q) error: got(%s) expected (%s)", data.content, got, expected)
+		}
+	}
+	if _, err := peruse.Read(peruse.Script("any", "  \n ")); err != io.EOF {
+		t.Errorf("Read of blank script returns (%v) expected (%v)", err, io.EOF)
+	}
+}
+
+func TestReparse(t *testing.T) {
+	content := "(define a 1)\n(define (f x)\n  (+ x a))\n\n(print \"a\nb\") (f 2) tail\n(g)"
+	table := []struct {
+		line, column int
+		toLine, toColumn int
+		replacement string
+	}{
+		{1, 9, 1, 10, "abc"},
+		{1, 13, 1, 13, " (x)"},
+		{2, 9, 2, 14, "g"},
+		{3, 3, 3, 10, ""},
+		{1, 1, 7, 4, "(new)"},
+		{4, 1, 4, 1, "\n\n\n"},
+		{5, 1, 5, 1, "x"},
+		{6, 2, 6, 3, ""},
+		{6, 10, 6, 10, "s"},
+		{7, 1, 7, 1, "  "},
+		{2, 1, 2, 2, ""},
+		{1, 12, 1, 12, "\n"},
+		{7, 4, 7, 4, " 1"},
+		{7, 3, 7, 3, " 1"},
+		{1, 1, 1, 1, "\n  "},
+	}
+	for _, data := range table {
+		tree, err := peruse.Parse("any", content)
+		if err != nil {
+			t.Fatalf("Parse returns unexpected error (%v)", err)
+		}
+		span := peruse.NewSpan(
+			peruse.NewLocation("any", data.line, data.column),
+			peruse.NewLocation("any", data.toLine, data.toColumn),
+		)
+		reparsed, err := peruse.Reparse(tree, peruse.NewEdit(span, data.replacement))
+		full, fullErr := peruse.Parse("any", edit(content, data.line, data.column, data.toLine, data.toColumn, data.replacement))
+		if (err == nil) != (fullErr == nil) {
+			t.Errorf("Reparse(%s, %q) error (%v), full parse error (%v)", span, data.replacement, err, fullErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(reparsed.Forms(), full.Forms()) {
+			t.Errorf("Reparse(%s, %q) got (%v) expected (%v)", span, data.replacement, reparsed.Forms(), full.Forms())
+		}
+	}
+}
+
+func TestReparseConditionals(t *testing.T) {
+	content := "#?(foo 1 :default 2)\n(b)\n#+foo\n  #0=(c #0#)\n#-foo (skipped) (d)"
+	options := []peruse.Option{peruse.Features("foo"), peruse.DatumLabels()}
+	table := []struct {
+		line, column int
+		toLine, toColumn int
+		replacement string
+	}{
+		{1, 19, 1, 20, "3"},
+		{1, 8, 1, 9, "x"},
+		{1, 4, 1, 7, "bar"},
+		{1, 1, 1, 1, " "},
+		{2, 2, 2, 3, "e"},
+		{3, 3, 3, 6, "bar"},
+		{4, 8, 4, 9, "x"},
+		{5, 8, 5, 15, "kept"},
+		{5, 1, 5, 1, "\n"},
+		{2, 4, 2, 4, " #?(:default x)"},
+	}
+	for _, data := range table {
+		tree, err := peruse.Parse("any", content, options...)
+		if err != nil {
+			t.Fatalf("Parse returns unexpected error (%v)", err)
+		}
+		span := peruse.NewSpan(
+			peruse.NewLocation("any", data.line, data.column),
+			peruse.NewLocation("any", data.toLine, data.toColumn),
+		)
+		reparsed, err := peruse.Reparse(tree, peruse.NewEdit(span, data.replacement))
+		full, fullErr := peruse.Parse("any", edit(content, data.line, data.column, data.toLine, data.toColumn, data.replacement), options...)
+		if (err == nil) != (fullErr == nil) {
+			t.Errorf("Reparse(%s, %q) error (%v), full parse error (%v)", span, data.replacement, err, fullErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(reparsed.Forms(), full.Forms()) {
+			t.Errorf("Reparse(%s, %q) got (%v) expected (%v)", span, data.replacement, reparsed.Forms(), full.Forms())
+		}
+	}
+}
+
+func TestReparseRandom(t *testing.T) {
+	pieces := []string{"#?(foo 1 :default 2)", "#?(bar (x) :default \"y\")", "\"s\"", "b1", "(a b)", " ", "\n", "#+foo x", "#-foo (y)", "#0=(c #0#)", ")", "("}
+	options := []peruse.Option{peruse.Features("foo"), peruse.DatumLabels()}
+	random := rand.New(rand.NewSource(1))
+	piece := func() string { return pieces[random.Intn(len(pieces))] }
+	for i := 0; i < 2000; i++ {
+		content := ""
+		for n := random.Intn(6); n >= 0; n-- {
+			content += piece()
+		}
+		tree, err := peruse.Parse("f", content, options...)
+		if err != nil {
+			continue
+		}
+		runes := []rune(content)
+		from := random.Intn(len(runes) + 1)
+		to := from + random.Intn(len(runes)-from+1)
+		replacement := ""
+		if random.Intn(2) == 0 {
+			replacement = piece()
+		}
+		span := peruse.NewSpan(locate(runes, from), locate(runes, to))
+		reparsed, err := peruse.Reparse(tree, peruse.NewEdit(span, replacement))
+		full, fullErr := peruse.Parse("f", string(runes[:from])+replacement+string(runes[to:]), options...)
+		if fmt.Sprint(err) != fmt.Sprint(fullErr) {
+			t.Errorf("Reparse(%q, %s, %q) error: got(%v) expected (%v)", content, span, replacement, err, fullErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(reparsed.Forms(), full.Forms()) {
+			t.Errorf("Reparse(%q, %s, %q) got (%v) expected (%v)", content, span, replacement, reparsed.Forms(), full.Forms())
+		}
+	}
+}
+
+// locate returns the location of the rune at offset of runes.
+func locate(runes []rune, offset int) peruse.Location {
+	line, column := 1, 1
+	for _, r := range runes[:offset] {
+		if r == '\n' {
+			line, column = line+1, 1
+			continue
+		}
+		column++
+	}
+	return peruse.NewLocation("f", line, column)
+}
+
+func edit(content string, line, column, toLine, toColumn int, replacement string) string {
+	lines := strings.SplitAfter(content, "\n")
+	offset := func(line, column int) int {
+		n := 0
+		for _, l := range lines[:line-1] {
+			n += len(l)
+		}
+		return n + len(string([]rune(lines[line-1])[:column-1]))
+	}
+	return content[:offset(line, column)] + replacement + content[offset(toLine, toColumn):]
+}
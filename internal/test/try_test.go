@@ -0,0 +1,100 @@
+package test
+
+import(
+	"errors"
+	"testing"
+	"github.com/begopher/peruse"
+)
+
+func TestTryEat(t *testing.T) {
+	word := func(text peruse.Text) (string, error) { return text.TryEatWord() }
+	symbol := func(text peruse.Text) (string, error) { return text.TryEatSymbol() }
+	keyword := func(text peruse.Text) (string, error) { return text.TryEatKeyword() }
+	integer := func(text peruse.Text) (string, error) { return text.TryEatInteger() }
+	float := func(text peruse.Text) (string, error) { return text.TryEatFloat() }
+	str := func(text peruse.Text) (string, error) { return text.TryEatString() }
+	prefixed := func(text peruse.Text) (string, error) {
+		_, prefixed, err := text.TryEatPrefixedWord("#")
+		return prefixed, err
+	}
+	prefixedSymbol := func(text peruse.Text) (string, error) {
+		_, prefixed, err := text.TryEatPrefixedSymbol("@")
+		return prefixed, err
+	}
+	words := func(text peruse.Text) (string, error) {
+		first, second, err := text.TryEatWords()
+		return first + ":" + second, err
+	}
+	symbols := func(text peruse.Text) (string, error) {
+		first, second, err := text.TryEatSymbols()
+		return first + ":" + second, err
+	}
+	table := []struct {
+		eat func(peruse.Text) (string, error)
+		content string
+		value string
+		reason peruse.Reason
+		err string
+	}{
+		{eat: word, content: "any more", value: "any"},
+		{eat: word, content: "", err: "f:1:1: expected word, found end of text", reason: peruse.EndOfText},
+		{eat: word, content: "1any", err: "f:1:1: expected word, found '1'", reason: peruse.NotApplicable},
+		{eat: word, content: "an_y", err: "f:1:3: invalid character '_' in word", reason: peruse.InvalidChar},
+		{eat: symbol, content: "my-name)", value: "my-name"},
+		{eat: symbol, content: "my-name-", err: "f:1:8: symbol ends with '-'", reason: peruse.TrailingHyphen},
+		{eat: symbol, content: "éa.b", err: "f:1:3: invalid character '.' in symbol", reason: peruse.InvalidChar},
+		{eat: keyword, content: ":key", value: ":key"},
+		{eat: keyword, content: "key", err: "f:1:1: expected keyword, found 'k'", reason: peruse.NotApplicable},
+		{eat: keyword, content: ":", err: "f:1:2: expected keyword, found end of text", reason: peruse.EndOfText},
+		{eat: keyword, content: ":1a", err: "f:1:2: invalid character '1' in keyword", reason: peruse.InvalidChar},
+		{eat: keyword, content: ":a-b", err: "f:1:3: invalid character '-' in keyword", reason: peruse.InvalidChar},
+		{eat: prefixed, content: "#name", value: "#name"},
+		{eat: prefixed, content: "name", err: "f:1:1: expected word, found 'n'", reason: peruse.NotApplicable},
+		{eat: prefixed, content: "#", err: "f:1:2: expected word, found end of text", reason: peruse.EndOfText},
+		{eat: prefixedSymbol, content: "@na-", err: "f:1:4: symbol ends with '-'", reason: peruse.TrailingHyphen},
+		{eat: integer, content: "-12", value: "-12"},
+		{eat: integer, content: "-", err: "f:1:1: expected integer, found '-'", reason: peruse.NotApplicable},
+		{eat: integer, content: "12a3", err: "f:1:3: invalid character 'a' in integer", reason: peruse.InvalidChar},
+		{eat: integer, content: "+12_3", err: "f:1:4: invalid character '_' in integer", reason: peruse.InvalidChar},
+		{eat: float, content: "1.5", value: "1.5"},
+		{eat: float, content: "+1.5.", err: "f:1:5: invalid character '.' in float", reason: peruse.InvalidChar},
+		{eat: float, content: "1", err: "f:1:1: expected float, found '1'", reason: peruse.NotApplicable},
+		{eat: str, content: `"a\"b"`, value: `a\"b`},
+		{eat: str, content: `"a\"b`, err: "f:1:1: unterminated string", reason: peruse.Unterminated},
+		{eat: str, content: "a", err: "f:1:1: expected string, found 'a'", reason: peruse.NotApplicable},
+		{eat: words, content: "ns:name", value: "ns:name"},
+		{eat: words, content: "name more", err: "f:1:5: missing ':' in qualified word", reason: peruse.MissingSeparator},
+		{eat: words, content: "a:b:c", err: "f:1:4: unexpected ':' in qualified word", reason: peruse.ExtraSeparator},
+		{eat: words, content: "a:", err: "f:1:3: empty part in qualified word", reason: peruse.EmptyPart},
+		{eat: words, content: "a:1", err: "f:1:3: invalid character '1' in qualified word", reason: peruse.InvalidChar},
+		{eat: symbols, content: "a-:b", err: "f:1:2: qualified symbol ends with '-'", reason: peruse.TrailingHyphen},
+		{eat: symbols, content: "a:b-", err: "f:1:4: qualified symbol ends with '-'", reason: peruse.TrailingHyphen},
+	}
+	for _, data := range table {
+		text := peruse.Script("f", data.content)
+		value, err := data.eat(text)
+		if data.err == "" {
+			if err != nil || value != data.value {
+				t.Errorf("TryEat(%q) got (%s, %v) expected (%s)", data.content, value, err, data.value)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("TryEat(%q) expected error (%s)", data.content, data.err)
+			continue
+		}
+		if got, expected := err.Error(), data.err; got != expected {
+			t.Errorf("TryEat(%q) error: got(%s) expected (%s)", data.content, got, expected)
+		}
+		if !errors.Is(err, data.reason) {
+			t.Errorf("TryEat(%q) error (%v) is not (%v)", data.content, err, data.reason)
+		}
+		var failure peruse.Failure
+		if !errors.As(err, &failure) || failure.Reason() != data.reason {
+			t.Errorf("TryEat(%q) does not return a failure with reason (%v)", data.content, data.reason)
+		}
+		if got, expected := text.Remain(), data.content; got != expected {
+			t.Errorf("TryEat(%q) consumed content on failure, remain: got(%s)", data.content, got)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"unicode/utf8"
+)
+
+// Part is a part of an interpolated string, either literal text or
+// an expression written inside ${...}.
+type Part interface {
+	// Literal is the text of a literal part, escapes are kept as they
+	// are written like in EatString.
+	Literal() string
+	// Expression is the text inside ${...}, it starts at the location
+	// where the expression is written and reads like the text it was
+	// eaten from. It is nil for literal parts.
+	Expression() Text
+	// Span includes the ${ and } of an expression.
+	Span() Span
+}
+
+type part struct {
+	literal    string
+	expression Text
+	span       Span
+}
+
+func (p part) Literal() string {
+	return p.literal
+}
+
+func (p part) Expression() Text {
+	return p.expression
+}
+
+func (p part) Span() Span {
+	return p.span
+}
+
+// EatInterpolatedString eats a string and splits it into literal parts
+// and expressions written as ${...}, \" and \$ are escapes so \${ is
+// literal text. Braces inside an expression nest and strings inside it
+// may contain braces. Empty literal parts are left out.
+func (s *script) EatInterpolatedString() ([]Part, error) {
+	const lexeme = "interpolated string"
+	if err := s.starts(lexeme, func(r rune) bool { return r == '"' }); err != nil {
+		return nil, err
+	}
+	parts := []Part{}
+	line, column := s.line, s.column+1
+	from, start := 1, NewLocation(s.origin, line, column)
+	literal := func(to int) {
+		if to > from {
+			parts = append(parts, part{literal: s.content[from:to], span: NewSpan(start, NewLocation(s.origin, line, column))})
+		}
+	}
+	for i := 1; i < len(s.content); {
+		c := s.content[i]
+		if c == '"' {
+			literal(i)
+			s.pass(i + 1)
+			return parts, nil
+		}
+		if c == '\\' && i+1 < len(s.content) && (s.content[i+1] == '"' || s.content[i+1] == '$') {
+			i, column = i+2, column+2
+			continue
+		}
+		if c == '$' && i+1 < len(s.content) && s.content[i+1] == '{' {
+			literal(i)
+			at := NewLocation(s.origin, line, column)
+			end := closing(s.content, i+2)
+			if end < 0 {
+				return nil, NewFailure(at, Unterminated, "interpolation", '$')
+			}
+			if end == i+2 {
+				return nil, NewFailure(at, EmptyPart, "interpolation", '}')
+			}
+			expression := s.sub(s.content[i+2:end], line, column+2)
+			line, column = s.advance(s.content[i:end+1], line, column)
+			parts = append(parts, part{expression: expression, span: NewSpan(at, NewLocation(s.origin, line, column))})
+			i, from, start = end+1, end+1, NewLocation(s.origin, line, column)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s.content[i:])
+		line, column = s.advance(s.content[i:i+size], line, column)
+		i += size
+	}
+	return nil, s.fail(0, Unterminated, lexeme)
+}
+
+// closing returns the offset of the brace that closes the one opened
+// before the offset from, or -1 when it is not closed.
+func closing(content string, from int) int {
+	depth := 1
+	for i := from; i < len(content); i++ {
+		switch content[i] {
+		case '"':
+			for i++; i < len(content) && content[i] != '"'; i++ {
+				if content[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(content) {
+				return -1
+			}
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// advance returns the line and column found after text when it starts
+// at line and column.
+func (s *script) advance(text string, line, column int) (int, int) {
+	for _, r := range text {
+		if r == '\n' {
+			line, column = line+1, s.columnReset
+			continue
+		}
+		column++
+	}
+	return line, column
+}
+
+// sub returns a text of content, which is written at line and column,
+// with the options of s.
+func (s *script) sub(content string, line, column int) Text {
+	t := *s
+	t.content = content
+	t.length = utf8.RuneCountInString(content)
+	t.line, t.column = line, column
+	t.size, t.forms = len(content), 0
+	return &t
+}
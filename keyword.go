@@ -0,0 +1,141 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"strings"
+)
+
+// KeywordSyntax is the set of keyword forms accepted by a dialect.
+type KeywordSyntax int
+
+const (
+	// ColonKeyword is :name.
+	ColonKeyword KeywordSyntax = 1 << iota
+	// AutoResolvedKeyword is ::name.
+	AutoResolvedKeyword
+	// NamespacedKeyword is :ns/name (or ::ns/name).
+	NamespacedKeyword
+	// TrailingColonKeyword is name:.
+	TrailingColonKeyword
+	// HyphenatedKeyword accepts symbols as names (e.g. :my-key).
+	HyphenatedKeyword
+)
+
+const (
+	ClojureKeywords    = ColonKeyword | AutoResolvedKeyword | NamespacedKeyword | HyphenatedKeyword
+	CommonLispKeywords = ColonKeyword | HyphenatedKeyword
+	SmalltalkKeywords  = TrailingColonKeyword | HyphenatedKeyword
+)
+
+// Key is a keyword split into its parts.
+type Key interface {
+	// Namespace is empty unless the keyword is namespaced.
+	Namespace() string
+	Name() string
+	// AutoResolved tells whether the keyword was written ::name.
+	AutoResolved() bool
+	// String is the keyword as it is written.
+	String() string
+}
+
+type key struct {
+	namespace string
+	name      string
+	auto      bool
+	text      string
+}
+
+func (k key) Namespace() string {
+	return k.namespace
+}
+
+func (k key) Name() string {
+	return k.name
+}
+
+func (k key) AutoResolved() bool {
+	return k.auto
+}
+
+func (k key) String() string {
+	return k.text
+}
+
+func (s *script) EatKey() (Key, error) {
+	if s.policy() {
+		var found key
+		eaten := s.identify(func(t *script) []string {
+			k, _, reason := t.keyword()
+			if reason != 0 {
+				return []string{""}
+			}
+			t.skip(len(k.text))
+			found = k
+			return []string{k.text}
+		})
+		if eaten[0] != "" {
+			found.text = eaten[0]
+			found.namespace = s.identifier(found.namespace)
+			found.name = s.identifier(found.name)
+			return found, nil
+		}
+	}
+	k, at, reason := s.keyword()
+	if reason != 0 {
+		return nil, s.fail(at, reason, "keyword")
+	}
+	s.skip(len(k.text))
+	return k, nil
+}
+
+func (s *script) IsKeyword(value string) bool {
+	value = s.normal(value)
+	_, _, reason := splitKeyword(s.keywords, value)
+	return reason == 0
+}
+
+// keyword parses the keyword content starts with, on failure it
+// returns the offset of the problem and why.
+func (s *script) keyword() (key, int, Reason) {
+	if len(s.content) == 0 {
+		return key{}, 0, EndOfText
+	}
+	k, at, reason := splitKeyword(s.keywords, s.until())
+	if reason == InvalidChar && at == len(s.content) {
+		reason = EndOfText
+	}
+	return k, at, reason
+}
+
+func splitKeyword(syntax KeywordSyntax, token string) (key, int, Reason) {
+	k := key{text: token}
+	rest, offset := "", 0
+	switch {
+	case syntax&AutoResolvedKeyword != 0 && strings.HasPrefix(token, "::"):
+		rest, offset, k.auto = token[2:], 2, true
+	case syntax&ColonKeyword != 0 && strings.HasPrefix(token, ":"):
+		rest, offset = token[1:], 1
+	case syntax&TrailingColonKeyword != 0 && len(token) > 1 && strings.HasSuffix(token, ":"):
+		rest = token[:len(token)-1]
+	default:
+		return k, 0, NotApplicable
+	}
+	hyphen := syntax&HyphenatedKeyword != 0
+	if syntax&NamespacedKeyword != 0 && offset != 0 {
+		if namespace, name, found := strings.Cut(rest, "/"); found {
+			if at, reason := checkName(namespace, hyphen); reason != 0 {
+				return k, offset + at, reason
+			}
+			k.namespace = namespace
+			rest, offset = name, offset+len(namespace)+1
+		}
+	}
+	if at, reason := checkName(rest, hyphen); reason != 0 {
+		return k, offset + at, reason
+	}
+	k.name = rest
+	return k, 0, 0
+}
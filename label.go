@@ -0,0 +1,236 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"fmt"
+	"strings"
+)
+
+// DatumLabels makes the reader read datum labels: #n= labels the form
+// that follows it and #n# is that same form, so #0=(a #0#) is a list
+// that contains itself. Labels are local to a top-level form. Walking
+// the forms of such a script must stop at lists already visited, as
+// Walk does, or reject the forms Cyclic finds.
+func DatumLabels() Option {
+	return func(s *script) {
+		s.labels = true
+	}
+}
+
+// label is a datum label read by the reader, form is nil while the
+// datum it labels is read unless the datum is a list.
+type label struct {
+	location Location
+	form     Form
+}
+
+// shared is a list with a datum label, the reader returns the same
+// shared for the list and for every reference to its label.
+type shared struct {
+	form
+}
+
+func (s *shared) String() string {
+	return write(s)
+}
+
+// labelling tells whether text reads datum labels.
+func labelling(text Text) bool {
+	s, ok := text.(*script)
+	return ok && s.labels
+}
+
+// datumLabel returns the datum label content starts with, #n= or #n#.
+func datumLabel(content string) string {
+	if len(content) == 0 || content[0] != '#' {
+		return ""
+	}
+	i := 1
+	for i < len(content) && '0' <= content[i] && content[i] <= '9' {
+		i++
+	}
+	if i == 1 || i == len(content) || (content[i] != '=' && content[i] != '#') {
+		return ""
+	}
+	return content[:i+1]
+}
+
+// label reads name, a datum label that starts at start and offset,
+// and the datum it labels or the form it refers to.
+func (r reader) label(name string, start Location, offset int) (Form, error) {
+	text := r.text
+	text.Eat(name)
+	n := name[1 : len(name)-1]
+	if r.skipping {
+		return r.ignore(name, start, offset)
+	}
+	if strings.HasSuffix(name, "#") {
+		l, ok := r.labels[n]
+		if !ok {
+			return nil, NewDiagnostic(start, fmt.Sprintf("undefined label %s", name))
+		}
+		if l.form == nil {
+			return nil, NewDiagnostic(start, fmt.Sprintf("label %s refers to a datum that is not read yet", name))
+		}
+		return l.form, nil
+	}
+	if l, ok := r.labels[n]; ok {
+		return nil, NewDiagnostic(start, fmt.Sprintf("duplicate label %s, first defined at %s", name, l.location))
+	}
+	l := &label{location: start}
+	r.labels[n] = l
+	space(text)
+	if text.Empty() || text.BeginWith(")") {
+		return nil, NewDiagnostic(start, fmt.Sprintf("missing datum after %s", name))
+	}
+	if text.BeginWith("(") {
+		s := &shared{}
+		l.form = s
+		f, err := r.list()
+		if err != nil {
+			return nil, err
+		}
+		s.form = plain(f)
+		s.span, s.start = NewSpan(start, s.span.End()), offset
+		return s, nil
+	}
+	f, err := r.datum()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := f.(spliced); ok {
+		return nil, NewDiagnostic(start, fmt.Sprintf("missing datum after %s", name))
+	}
+	if v, ok := f.(form); ok {
+		v.span, v.start = NewSpan(start, v.span.End()), offset
+		f = v
+	}
+	l.form = f
+	return f, nil
+}
+
+// ignore reads a datum label name of a skipped form, a reference is
+// read as an atom and a label as the datum it labels.
+func (r reader) ignore(name string, start Location, offset int) (Form, error) {
+	if strings.HasSuffix(name, "#") {
+		return r.atom(Atom, name, start, offset), nil
+	}
+	space(r.text)
+	if r.text.Empty() || r.text.BeginWith(")") {
+		return nil, NewDiagnostic(start, fmt.Sprintf("missing datum after %s", name))
+	}
+	return r.datum()
+}
+
+// plain returns the form f is made of, f is read by a reader.
+func plain(f Form) form {
+	if s, ok := f.(*shared); ok {
+		return s.form
+	}
+	return f.(form)
+}
+
+// labelled tells whether any of forms holds a list with a datum label.
+func labelled(forms ...Form) bool {
+	for _, f := range forms {
+		if _, ok := f.(*shared); ok || labelled(f.Forms()...) {
+			return true
+		}
+	}
+	return false
+}
+
+// Walk calls visit with f and the forms inside it, a list is visited
+// before its forms. A list with a datum label is walked once, so the
+// walk of a form that contains itself ends.
+func Walk(f Form, visit func(Form)) {
+	seen := map[*shared]bool{}
+	var walk func(Form)
+	walk = func(f Form) {
+		if s, ok := f.(*shared); ok {
+			if seen[s] {
+				return
+			}
+			seen[s] = true
+		}
+		visit(f)
+		for _, child := range f.Forms() {
+			walk(child)
+		}
+	}
+	walk(f)
+}
+
+// Cyclic tells whether f contains itself through a datum label.
+func Cyclic(f Form) bool {
+	inside := map[*shared]bool{}
+	var cyclic func(Form) bool
+	cyclic = func(f Form) bool {
+		s, ok := f.(*shared)
+		if ok {
+			if inside[s] {
+				return true
+			}
+			inside[s] = true
+			defer delete(inside, s)
+		}
+		for _, child := range f.Forms() {
+			if cyclic(child) {
+				return true
+			}
+		}
+		return false
+	}
+	return cyclic(f)
+}
+
+// write prints f, a list with a datum label that is reached more than
+// once is labelled #n= where it is first printed and written #n# after.
+func write(f Form) string {
+	seen := map[*shared]int{}
+	var walk func(Form)
+	walk = func(f Form) {
+		if s, ok := f.(*shared); ok {
+			if seen[s]++; seen[s] > 1 {
+				return
+			}
+		}
+		for _, child := range f.Forms() {
+			walk(child)
+		}
+	}
+	walk(f)
+	labels := map[*shared]int{}
+	var b strings.Builder
+	var print func(Form)
+	print = func(f Form) {
+		if s, ok := f.(*shared); ok && seen[s] > 1 {
+			if n, ok := labels[s]; ok {
+				fmt.Fprintf(&b, "#%d#", n)
+				return
+			}
+			labels[s] = len(labels)
+			fmt.Fprintf(&b, "#%d=", labels[s])
+		}
+		switch f.Kind() {
+		case List:
+			b.WriteByte('(')
+			for i, child := range f.Forms() {
+				if i != 0 {
+					b.WriteByte(' ')
+				}
+				print(child)
+			}
+			b.WriteByte(')')
+		case String, RawString:
+			b.WriteString(f.String())
+		default:
+			b.WriteString(f.Value())
+		}
+	}
+	print(f)
+	return b.String()
+}
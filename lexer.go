@@ -0,0 +1,147 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"errors"
+	"io"
+	"github.com/begopher/peruse/internal/numeric"
+)
+
+// Token is a lexeme of a script as it is written in the source.
+type Token interface {
+	Kind() Kind
+	// Text is the source of the token, strings keep their quotes.
+	Text() string
+	Span() Span
+}
+
+type token struct {
+	kind Kind
+	text string
+	span Span
+}
+
+func (t token) Kind() Kind {
+	return t.kind
+}
+
+func (t token) Text() string {
+	return t.text
+}
+
+func (t token) Span() Span {
+	return t.span
+}
+
+// Lex skips leading spaces and eats the next token of text, comments
+// included. io.EOF is returned when nothing is left.
+func Lex(text Text) (Token, error) {
+	if err := input(text); err != nil {
+		return nil, err
+	}
+	text.EatSpaces()
+	if text.Empty() {
+		return nil, io.EOF
+	}
+	start, remain := text.Location(), text.Remain()
+	kind := Open
+	switch {
+	case text.Eat("("):
+	case text.Eat(")"):
+		kind = Close
+	case text.EatComment() != "":
+		kind = Comment
+	default:
+		var err error
+		if kind, _, err = lexeme(text); err != nil {
+			return nil, err
+		}
+	}
+	source := remain[:len(remain)-len(text.Remain())]
+	return token{kind, source, NewSpan(start, text.Location())}, nil
+}
+
+// lexeme eats the atom text starts with and returns its kind and
+// value, the limits of text are checked.
+func lexeme(text Text) (Kind, string, error) {
+	start := text.Location()
+	kind, value, err := scan(text)
+	if err != nil {
+		return 0, "", err
+	}
+	if err := check(text, kind, value, start); err != nil {
+		return 0, "", err
+	}
+	return kind, value, nil
+}
+
+func scan(text Text) (Kind, string, error) {
+	if text.BeginWith(")") {
+		return 0, "", NewDiagnostic(text.Location(), "unexpected ')'")
+	}
+	if kind, value := text.EatLexeme(); value != "" {
+		return kind, value, nil
+	}
+	if text.BeginWith(`"""`) {
+		literal, err := text.EatHeredoc()
+		if err != nil {
+			return 0, "", err
+		}
+		return RawString, literal.Value(), nil
+	}
+	if raw(text.Remain()) {
+		literal, err := text.EatRawString()
+		if err != nil {
+			return 0, "", err
+		}
+		return RawString, literal.Value(), nil
+	}
+	if text.BeginWith(`"`) {
+		value, err := text.TryEatString()
+		if err != nil {
+			return 0, "", err
+		}
+		return String, value, nil
+	}
+	if quantity, err := text.EatQuantity(); err == nil {
+		return Measure, quantity.String(), nil
+	} else if !errors.Is(err, NotApplicable) && !errors.Is(err, EndOfText) {
+		return 0, "", err
+	}
+	if value := text.EatInteger(); value != "" {
+		return Integer, value, nil
+	}
+	if value := text.EatFloat(); value != "" {
+		return Float, value, nil
+	}
+	if value := text.EatKeyword(); value != "" {
+		return Keyword, value, nil
+	}
+	if value := text.EatSymbol(); value != "" {
+		return Symbol, value, nil
+	}
+	value := atom(text.Remain())
+	if value == "" || !text.Eat(value) {
+		return 0, "", NewDiagnostic(text.Location(), "unexpected character")
+	}
+	return Atom, value, nil
+}
+
+// atom returns the characters of content up to the first delimiter.
+func atom(content string) string {
+	for i, r := range content {
+		if delimiter(r) {
+			return content[:i]
+		}
+	}
+	return content
+}
+
+// delimiter tells whether r ends a token, the Eat methods stop at the
+// same runes so a token is classified whatever follows it.
+func delimiter(r rune) bool {
+	return numeric.Delimiter(r)
+}
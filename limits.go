@@ -0,0 +1,106 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"fmt"
+	"unicode"
+)
+
+// Limits bounds the work done on untrusted content, a zero field is
+// no limit. They are enforced by Read, ReadRecover, Parse and ParseCST,
+// which fail with the LimitExceeded reason. Lex reads no lists, it
+// enforces all but Depth and Forms.
+type Limits struct {
+	// Input is the size of the content in bytes.
+	Input int
+	// Token is the length in bytes of an atom other than a string.
+	Token int
+	// String is the length in bytes of a string without its quotes.
+	String int
+	// Depth is how deep lists can be nested.
+	Depth int
+	// Forms is the number of forms read from the script, nested
+	// ones included.
+	Forms int
+	// Digits is the number of digits of an integer or a float.
+	Digits int
+}
+
+func WithLimits(limits Limits) Option {
+	return func(s *script) {
+		s.limits = limits
+	}
+}
+
+// limited returns the script of text when it has limits.
+func limited(text Text) *script {
+	s, ok := text.(*script)
+	if !ok || s.limits == (Limits{}) {
+		return nil
+	}
+	return s
+}
+
+func exceeded(location Location, format string, args ...any) Failure {
+	return NewFailure(location, LimitExceeded, fmt.Sprintf(format, args...), 0)
+}
+
+// input checks the size of the content of text.
+func input(text Text) error {
+	s := limited(text)
+	if s == nil || s.limits.Input == 0 || s.size <= s.limits.Input {
+		return nil
+	}
+	return exceeded(s.Location(), "input larger than %d bytes", s.limits.Input)
+}
+
+// count counts a form that starts at location.
+func count(text Text, location Location) error {
+	s := limited(text)
+	if s == nil || s.limits.Forms == 0 {
+		return nil
+	}
+	if s.forms++; s.forms > s.limits.Forms {
+		return exceeded(location, "more than %d forms", s.limits.Forms)
+	}
+	return nil
+}
+
+// nest checks the depth of a list that starts at location.
+func nest(text Text, depth int, location Location) error {
+	s := limited(text)
+	if s == nil || s.limits.Depth == 0 || depth <= s.limits.Depth {
+		return nil
+	}
+	return exceeded(location, "lists nested deeper than %d", s.limits.Depth)
+}
+
+// check checks an atom of kind with value that starts at location.
+func check(text Text, kind Kind, value string, location Location) error {
+	s := limited(text)
+	if s == nil {
+		return nil
+	}
+	limits := s.limits
+	switch {
+	case (kind == String || kind == RawString) && limits.String != 0 && len(value) > limits.String:
+		return exceeded(location, "string longer than %d bytes", limits.String)
+	case kind != String && kind != RawString && limits.Token != 0 && len(value) > limits.Token:
+		return exceeded(location, "token longer than %d bytes", limits.Token)
+	}
+	if (kind == Integer || kind == Float || kind == Measure) && limits.Digits != 0 {
+		digits := 0
+		for _, r := range value {
+			if unicode.IsDigit(r) {
+				digits++
+			}
+		}
+		if digits > limits.Digits {
+			return exceeded(location, "number with more than %d digits", limits.Digits)
+		}
+	}
+	return nil
+}
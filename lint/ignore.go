@@ -0,0 +1,128 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package lint
+
+import(
+	"errors"
+	"io"
+	"strings"
+	"github.com/begopher/peruse"
+)
+
+const directive = "peruse:ignore"
+
+// ignore is a suppression comment, it covers the lines from first
+// to last, rules is empty when all rules are ignored.
+type ignore struct {
+	first int
+	last  int
+	rules []string
+}
+
+func (i ignore) covers(d Diagnostic) bool {
+	line := d.Location().Line()
+	if line < i.first || line > i.last {
+		return false
+	}
+	if len(i.rules) == 0 {
+		return true
+	}
+	for _, rule := range i.rules {
+		if rule == d.Rule() {
+			return true
+		}
+	}
+	return false
+}
+
+func suppress(tree peruse.Tree, diagnostics []Diagnostic) []Diagnostic {
+	ignores := ignores(tree)
+	if len(ignores) == 0 {
+		return diagnostics
+	}
+	kept := []Diagnostic{}
+next:
+	for _, d := range diagnostics {
+		for _, i := range ignores {
+			if i.covers(d) {
+				continue next
+			}
+		}
+		kept = append(kept, d)
+	}
+	return kept
+}
+
+// ignores finds the suppression comments of tree.
+func ignores(tree peruse.Tree) []ignore {
+	tokens := lex(tree)
+	ignores := []ignore{}
+	for i, token := range tokens {
+		if token.Kind() != peruse.Comment {
+			continue
+		}
+		fields := strings.Fields(strings.TrimLeft(token.Text(), ";"))
+		if len(fields) == 0 || fields[0] != directive {
+			continue
+		}
+		rules := fields[1:]
+		line := token.Span().Start().Line()
+		if i > 0 && tokens[i-1].Span().End().Line() == line {
+			ignores = append(ignores, ignore{line, line, rules})
+			continue
+		}
+		if next := following(tokens[i+1:]); next != nil {
+			ignores = append(ignores, ignore{next.Start().Line(), next.End().Line(), rules})
+		}
+	}
+	return ignores
+}
+
+// lex returns the tokens of the content of tree, comments included,
+// since forms do not keep them. Text that fails to lex is skipped up
+// to the next delimiter.
+func lex(tree peruse.Tree) []peruse.Token {
+	tokens := []peruse.Token{}
+	text := peruse.Rescan(tree)
+	for {
+		token, err := peruse.Lex(text)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if text.SkipToDelimiter() == "" {
+				break
+			}
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// following returns the span of the form tokens start with,
+// comments before it are skipped.
+func following(tokens []peruse.Token) peruse.Span {
+	depth := 0
+	var start peruse.Location
+	for _, token := range tokens {
+		if token.Kind() == peruse.Comment && depth == 0 {
+			continue
+		}
+		if start == nil {
+			start = token.Span().Start()
+		}
+		switch token.Kind() {
+		case peruse.Open:
+			depth++
+		case peruse.Close:
+			depth--
+		}
+		if depth <= 0 {
+			return peruse.NewSpan(start, token.Span().End())
+		}
+	}
+	return nil
+}
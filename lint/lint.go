@@ -0,0 +1,229 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+// Package lint runs rules over parsed scripts, it is modelled on
+// golang.org/x/tools/go/analysis.
+//
+// A rule is a pass over one tree that reports diagnostics, with
+// optional suggested fixes, and returns a result that the rules
+// requiring it can read. Rules required by the ones being run are
+// run too but only the diagnostics of the latter are returned.
+//
+// A comment such as
+//
+//	; peruse:ignore rule-a rule-b
+//
+// suppresses the diagnostics of the rules it names, or of all rules
+// when it names none. At the end of a line it covers that line, on a
+// line of its own it covers the form that follows it.
+package lint
+
+import(
+	"errors"
+	"fmt"
+	"strings"
+	"github.com/begopher/peruse"
+)
+
+type Rule interface {
+	Name() string
+	Doc() string
+	Requires() []Rule
+	Run(pass Pass) (any, error)
+}
+
+func NewRule(name, doc string, run func(Pass) (any, error), requires ...Rule) Rule {
+	return rule{name, doc, run, requires}
+}
+
+type rule struct {
+	name     string
+	doc      string
+	run      func(Pass) (any, error)
+	requires []Rule
+}
+
+func (r rule) Name() string {
+	return r.name
+}
+
+func (r rule) Doc() string {
+	return r.doc
+}
+
+func (r rule) Requires() []Rule {
+	return r.requires
+}
+
+func (r rule) Run(pass Pass) (any, error) {
+	return r.run(pass)
+}
+
+// Pass is what a rule is given to check a tree.
+type Pass interface {
+	Tree() peruse.Tree
+	// Result is what a required rule returned.
+	Result(rule Rule) any
+	Report(location peruse.Location, message string, fixes ...Fix)
+}
+
+// Fix is a suggested change that resolves a diagnostic.
+type Fix interface {
+	Message() string
+	Edits() []peruse.Edit
+}
+
+func NewFix(message string, edits ...peruse.Edit) Fix {
+	return fix{message, edits}
+}
+
+type fix struct {
+	message string
+	edits   []peruse.Edit
+}
+
+func (f fix) Message() string {
+	return f.message
+}
+
+func (f fix) Edits() []peruse.Edit {
+	return f.edits
+}
+
+// Diagnostic is a problem reported by a rule.
+type Diagnostic interface {
+	peruse.Diagnostic
+	Rule() string
+	Fixes() []Fix
+}
+
+type diagnostic struct {
+	peruse.Diagnostic
+	rule  string
+	fixes []Fix
+}
+
+func (d diagnostic) Rule() string {
+	return d.rule
+}
+
+func (d diagnostic) Fixes() []Fix {
+	return d.fixes
+}
+
+func (d diagnostic) Error() string {
+	return fmt.Sprintf("%s (%s)", d.Diagnostic.Error(), d.rule)
+}
+
+type pass struct {
+	rule        Rule
+	tree        peruse.Tree
+	results     map[string]any
+	diagnostics *[]Diagnostic
+}
+
+func (p pass) Tree() peruse.Tree {
+	return p.tree
+}
+
+func (p pass) Result(rule Rule) any {
+	return p.results[rule.Name()]
+}
+
+func (p pass) Report(location peruse.Location, message string, fixes ...Fix) {
+	d := diagnostic{peruse.NewDiagnostic(location, message), p.rule.Name(), fixes}
+	*p.diagnostics = append(*p.diagnostics, d)
+}
+
+// Source returns a tree of content without forms, SourceRules can
+// check with it a script that fails to parse.
+func Source(origin, content string) peruse.Tree {
+	return source{origin, content}
+}
+
+type source struct {
+	origin  string
+	content string
+}
+
+func (s source) Origin() string {
+	return s.origin
+}
+
+func (s source) Content() string {
+	return s.content
+}
+
+func (s source) Forms() []peruse.Form {
+	return nil
+}
+
+// Run runs rules over tree, and the rules they require first, and
+// returns the diagnostics of rules that are not suppressed, sorted
+// by location.
+func Run(tree peruse.Tree, rules ...Rule) ([]Diagnostic, error) {
+	order, err := sortRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	reported := map[string]bool{}
+	for _, r := range rules {
+		reported[r.Name()] = true
+	}
+	results := map[string]any{}
+	diagnostics := []Diagnostic{}
+	for _, r := range order {
+		found := []Diagnostic{}
+		result, err := r.Run(pass{r, tree, results, &found})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r.Name(), err)
+		}
+		results[r.Name()] = result
+		if reported[r.Name()] {
+			diagnostics = append(diagnostics, found...)
+		}
+	}
+	diagnostics = suppress(tree, diagnostics)
+	sorted := make([]peruse.Diagnostic, len(diagnostics))
+	for i, d := range diagnostics {
+		sorted[i] = d
+	}
+	peruse.SortDiagnostics(sorted)
+	for i, d := range sorted {
+		diagnostics[i] = d.(Diagnostic)
+	}
+	return diagnostics, nil
+}
+
+// sortRules orders rules after the rules they require.
+func sortRules(rules []Rule) ([]Rule, error) {
+	order := []Rule{}
+	// state is 1 while the requirements of a rule are visited and 2 after
+	state := map[string]int{}
+	var visit func(r Rule, path []string) error
+	visit = func(r Rule, path []string) error {
+		path = append(path, r.Name())
+		switch state[r.Name()] {
+		case 1:
+			return errors.New("rules require each other: " + strings.Join(path, " -> "))
+		case 2:
+			return nil
+		}
+		state[r.Name()] = 1
+		for _, required := range r.Requires() {
+			if err := visit(required, path); err != nil {
+				return err
+			}
+		}
+		state[r.Name()] = 2
+		order = append(order, r)
+		return nil
+	}
+	for _, r := range rules {
+		if err := visit(r, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package lint
+
+import(
+	"fmt"
+	"strings"
+	"unicode/utf8"
+	"github.com/begopher/peruse"
+)
+
+// Rules returns the rules peruse comes with.
+func Rules() []Rule {
+	return []Rule{DuplicateDefine, EmptyBody, TrailingSpace, Bidi, Invisible, Confusable}
+}
+
+// SourceRules returns the rules that only read the content, they are
+// the ones that check the trees made by Source.
+func SourceRules() []Rule {
+	return []Rule{Bidi, Invisible, Confusable}
+}
+
+// Defines reports nothing, its result is the names ([]peruse.Form)
+// of the top-level defines in order.
+var Defines = NewRule("defines", "collects the names of top-level defines", func(pass Pass) (any, error) {
+	names := []peruse.Form{}
+	for _, form := range pass.Tree().Forms() {
+		if name := defined(form); name != nil {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+})
+
+var DuplicateDefine = NewRule("duplicate-define", "reports names defined twice at the top level", func(pass Pass) (any, error) {
+	first := map[string]peruse.Form{}
+	for _, name := range pass.Result(Defines).([]peruse.Form) {
+		if previous, ok := first[name.Value()]; ok {
+			pass.Report(name.Span().Start(), fmt.Sprintf("%s is already defined at %s", name.Value(), previous.Span().Start()))
+			continue
+		}
+		first[name.Value()] = name
+	}
+	return nil, nil
+}, Defines)
+
+var EmptyBody = NewRule("empty-body", "reports lambdas and function defines without a body", func(pass Pass) (any, error) {
+	visit := func(form peruse.Form) {
+		forms := form.Forms()
+		if len(forms) == 2 && head(form, "lambda") && forms[1].Kind() == peruse.List {
+			pass.Report(form.Span().Start(), "lambda has an empty body")
+		}
+		if len(forms) == 2 && head(form, "define") && forms[1].Kind() == peruse.List {
+			pass.Report(form.Span().Start(), "define has an empty body")
+		}
+	}
+	for _, form := range pass.Tree().Forms() {
+		peruse.Walk(form, visit)
+	}
+	return nil, nil
+})
+
+var TrailingSpace = NewRule("trailing-space", "reports spaces and tabs at the end of lines", func(pass Pass) (any, error) {
+	tree := pass.Tree()
+	literals := []peruse.Span{}
+	visit := func(form peruse.Form) {
+		if form.Kind() == peruse.String || form.Kind() == peruse.RawString {
+			literals = append(literals, form.Span())
+		}
+	}
+	for _, form := range tree.Forms() {
+		peruse.Walk(form, visit)
+	}
+	for i, line := range lines(tree.Content()) {
+		trimmed := trimBlanks(line)
+		if len(trimmed) == len(line) {
+			continue
+		}
+		column := utf8.RuneCountInString(trimmed) + 1
+		start := peruse.NewLocation(tree.Origin(), i+1, column)
+		if inside(literals, start) {
+			continue
+		}
+		end := peruse.NewLocation(tree.Origin(), i+1, utf8.RuneCountInString(line)+1)
+		pass.Report(start, "trailing space", NewFix("remove trailing space", peruse.NewEdit(peruse.NewSpan(start, end), "")))
+	}
+	return nil, nil
+})
+
+// defined returns the name a define form defines, or nil.
+func defined(form peruse.Form) peruse.Form {
+	forms := form.Forms()
+	if len(forms) < 2 || !head(form, "define") {
+		return nil
+	}
+	name := forms[1]
+	if name.Kind() == peruse.List {
+		if len(name.Forms()) == 0 {
+			return nil
+		}
+		name = name.Forms()[0]
+	}
+	if name.Kind() != peruse.Symbol && name.Kind() != peruse.Atom {
+		return nil
+	}
+	return name
+}
+
+func head(form peruse.Form, name string) bool {
+	forms := form.Forms()
+	return form.Kind() == peruse.List && len(forms) != 0 && forms[0].Kind() == peruse.Symbol && forms[0].Value() == name
+}
+
+func lines(content string) []string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+func trimBlanks(line string) string {
+	return strings.TrimRight(line, " \t")
+}
+
+// inside tells whether location is within one of spans.
+func inside(spans []peruse.Span, location peruse.Location) bool {
+	before := func(a, b peruse.Location) bool {
+		return a.Line() < b.Line() || a.Line() == b.Line() && a.Column() < b.Column()
+	}
+	for _, span := range spans {
+		if !before(location, span.Start()) && before(location, span.End()) {
+			return true
+		}
+	}
+	return false
+}
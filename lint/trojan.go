@@ -0,0 +1,171 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package lint
+
+import(
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"github.com/begopher/peruse"
+)
+
+// bidi are the bidirectional control characters of Trojan Source
+// (CVE-2021-42574).
+var bidi = map[rune]string{
+	'\u061C': "ARABIC LETTER MARK",
+	'\u200E': "LEFT-TO-RIGHT MARK",
+	'\u200F': "RIGHT-TO-LEFT MARK",
+	'\u202A': "LEFT-TO-RIGHT EMBEDDING",
+	'\u202B': "RIGHT-TO-LEFT EMBEDDING",
+	'\u202C': "POP DIRECTIONAL FORMATTING",
+	'\u202D': "LEFT-TO-RIGHT OVERRIDE",
+	'\u202E': "RIGHT-TO-LEFT OVERRIDE",
+	'\u2066': "LEFT-TO-RIGHT ISOLATE",
+	'\u2067': "RIGHT-TO-LEFT ISOLATE",
+	'\u2068': "FIRST STRONG ISOLATE",
+	'\u2069': "POP DIRECTIONAL ISOLATE",
+}
+
+// invisible are characters that print as nothing.
+var invisible = map[rune]string{
+	'\u00AD': "SOFT HYPHEN",
+	'\u115F': "HANGUL CHOSEONG FILLER",
+	'\u1160': "HANGUL JUNGSEONG FILLER",
+	'\u180E': "MONGOLIAN VOWEL SEPARATOR",
+	'\u200B': "ZERO WIDTH SPACE",
+	'\u200C': "ZERO WIDTH NON-JOINER",
+	'\u200D': "ZERO WIDTH JOINER",
+	'\u2060': "WORD JOINER",
+	'\u2061': "FUNCTION APPLICATION",
+	'\u2062': "INVISIBLE TIMES",
+	'\u2063': "INVISIBLE SEPARATOR",
+	'\u2064': "INVISIBLE PLUS",
+	'\u3164': "HANGUL FILLER",
+	'\uFEFF': "ZERO WIDTH NO-BREAK SPACE",
+	'\uFFA0': "HALFWIDTH HANGUL FILLER",
+}
+
+var Bidi = NewRule("bidi", "reports bidirectional control characters", func(pass Pass) (any, error) {
+	each(pass.Tree(), func(r rune, location peruse.Location) {
+		if name, ok := bidi[r]; ok {
+			pass.Report(location, fmt.Sprintf("bidirectional control character U+%04X (%s) can make the code read differently from how it runs", r, name))
+		}
+	})
+	return nil, nil
+})
+
+var Invisible = NewRule("invisible", "reports characters that print as nothing", func(pass Pass) (any, error) {
+	each(pass.Tree(), func(r rune, location peruse.Location) {
+		if location.Line() == 1 && location.Column() == 1 && r == '\uFEFF' {
+			return // byte order mark
+		}
+		if name, ok := invisible[r]; ok {
+			pass.Report(location, fmt.Sprintf("invisible character U+%04X (%s) hides what the code contains", r, name))
+		}
+	})
+	return nil, nil
+})
+
+// each calls visit with every rune of the content of tree.
+func each(tree peruse.Tree, visit func(rune, peruse.Location)) {
+	line, column := 1, 1
+	for _, r := range tree.Content() {
+		visit(r, peruse.NewLocation(tree.Origin(), line, column))
+		if r == '\n' {
+			line, column = line+1, 1
+			continue
+		}
+		column++
+	}
+}
+
+// prototypes maps letters to the Latin letter they are confused
+// with, a subset of the confusables of UTS #39 for the Cyrillic,
+// Greek and Latin look-alikes. Look-alikes from other scripts, and
+// the ones made of many characters, are not found.
+var prototypes = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'ѕ': 's', 'і': 'i',
+	'ј': 'j', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w', 'ӏ': 'l', 'һ': 'h', 'ԍ': 'g',
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O',
+	'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'Ѕ': 'S', 'І': 'I', 'Ј': 'J',
+	'Ү': 'Y', 'Ԁ': 'D', 'Ԛ': 'Q', 'Ԝ': 'W',
+	// Greek
+	'α': 'a', 'ο': 'o', 'ν': 'v', 'ι': 'i', 'κ': 'k', 'ρ': 'p', 'τ': 't',
+	'υ': 'u', 'χ': 'x', 'γ': 'y',
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K',
+	'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	// Latin and digits
+	'ı': 'i', 'ɑ': 'a', 'ɡ': 'g', '0': 'O', '1': 'l',
+}
+
+// skeleton is the UTS #39 skeleton of value for the prototypes above.
+func skeleton(value string) string {
+	return strings.Map(func(r rune) rune {
+		if p, ok := prototypes[r]; ok {
+			return p
+		}
+		return r
+	}, value)
+}
+
+// scripts are the scripts mixing is looked for in.
+var scripts = []string{"Latin", "Cyrillic", "Greek", "Armenian", "Hebrew", "Arabic", "Cherokee"}
+
+// script returns the script of r among scripts, or "".
+func script(r rune) string {
+	for _, name := range scripts {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return name
+		}
+	}
+	return ""
+}
+
+// Confusable reads the identifiers from the content, so it checks a
+// script that fails to parse, and only knows the look-alikes above.
+var Confusable = NewRule("confusable", "reports identifiers that mix scripts or look like another identifier", func(pass Pass) (any, error) {
+	// seen maps skeletons to the first identifier that has it
+	seen := map[string]peruse.Token{}
+	for _, token := range lex(pass.Tree()) {
+		switch token.Kind() {
+		case peruse.Symbol, peruse.Keyword, peruse.Atom:
+		default:
+			continue
+		}
+		value := token.Text()
+		if mixed := mixedScripts(value); len(mixed) > 1 {
+			pass.Report(token.Span().Start(), fmt.Sprintf("%s mixes %s letters, some may only look like the letters they stand for", value, strings.Join(mixed, " and ")))
+		}
+		key := skeleton(value)
+		first, ok := seen[key]
+		if !ok {
+			seen[key] = token
+			continue
+		}
+		if first.Text() != value {
+			pass.Report(token.Span().Start(), fmt.Sprintf("%s looks like %s at %s but is a different name", value, first.Text(), first.Span().Start()))
+		}
+	}
+	return nil, nil
+})
+
+// mixedScripts returns the scripts of the letters of value, sorted.
+func mixedScripts(value string) []string {
+	found := map[string]bool{}
+	for _, r := range value {
+		if name := script(r); name != "" {
+			found[name] = true
+		}
+	}
+	names := []string{}
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
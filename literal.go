@@ -0,0 +1,196 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"strings"
+	"unicode/utf8"
+)
+
+// Literal is a string literal that is not written with escapes, its
+// value may differ from its source (e.g. the indentation stripped from
+// a heredoc), Location maps the value back to where it is written.
+type Literal interface {
+	Value() string
+	Span() Span
+	// Location returns where the byte at offset of Value is written.
+	Location(offset int) Location
+}
+
+type literal struct {
+	value  string
+	span   Span
+	origin string
+	pieces []piece
+}
+
+// piece is a line of a literal, offset is where it starts in the
+// value and line and column where it starts in the source.
+type piece struct {
+	offset int
+	line   int
+	column int
+}
+
+func (l literal) Value() string {
+	return l.value
+}
+
+func (l literal) Span() Span {
+	return l.span
+}
+
+func (l literal) Location(offset int) Location {
+	offset = max(0, min(offset, len(l.value)))
+	p := l.pieces[0]
+	for _, next := range l.pieces[1:] {
+		if next.offset > offset {
+			break
+		}
+		p = next
+	}
+	return NewLocation(l.origin, p.line, p.column+utf8.RuneCountInString(l.value[p.offset:offset]))
+}
+
+// EatRawString eats a raw string, #r"..."#, nothing is escaped inside
+// it and it ends at the first quote followed by as many hashes as it
+// starts with, so ##r"a "# b"## is the value a "# b.
+func (s *script) EatRawString() (Literal, error) {
+	const lexeme = "raw string"
+	hashes := len(s.content) - len(strings.TrimLeft(s.content, "#"))
+	if hashes == 0 {
+		return nil, s.starts(lexeme, func(r rune) bool { return r == '#' })
+	}
+	if err := s.expect(hashes, `r"`, lexeme); err != nil {
+		return nil, err
+	}
+	open := hashes + 2
+	closing := `"` + s.content[:hashes]
+	end := strings.Index(s.content[open:], closing)
+	if end < 0 {
+		return nil, s.fail(0, Unterminated, lexeme)
+	}
+	value := s.content[open : open+end]
+	start := s.Location()
+	pieces := []piece{{0, s.line, s.at(open).Column()}}
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\n' {
+			pieces = append(pieces, piece{i + 1, s.line + len(pieces), s.columnReset})
+		}
+	}
+	s.pass(open + end + len(closing))
+	return literal{value, NewSpan(start, s.Location()), s.origin, pieces}, nil
+}
+
+// EatHeredoc eats a triple quoted string, the opening quotes end their
+// line and the value starts on the next one. The blanks common to the
+// start of all lines which are not blank are stripped, closing quotes
+// on their own line count as a line and do not add a new line to the
+// value. Lines may end with \r\n, the value has \n. Nothing is
+// escaped, the value ends at the first """.
+func (s *script) EatHeredoc() (Literal, error) {
+	const lexeme = "heredoc"
+	const quotes = `"""`
+	if err := s.expect(0, quotes, lexeme); err != nil {
+		return nil, err
+	}
+	open := len(quotes) + len(indentation(s.content[len(quotes):]))
+	if open == len(s.content) {
+		return nil, s.fail(open, EndOfText, lexeme)
+	}
+	if strings.HasPrefix(s.content[open:], "\r\n") {
+		open++
+	}
+	if s.content[open] != '\n' {
+		return nil, s.fail(open, InvalidChar, lexeme)
+	}
+	open++
+	end := strings.Index(s.content[open:], quotes)
+	if end < 0 {
+		return nil, s.fail(0, Unterminated, lexeme)
+	}
+	lines := strings.Split(s.content[open:open+end], "\n")
+	for i, line := range lines[:len(lines)-1] {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	closing := strings.TrimLeft(lines[len(lines)-1], " \t") == ""
+	common, found := "", false
+	for i, line := range lines {
+		if strings.TrimLeft(line, " \t") == "" && !(closing && i == len(lines)-1) {
+			continue
+		}
+		if blanks := indentation(line); !found {
+			common, found = blanks, true
+		} else {
+			common = common[:commonPrefix(common, blanks)]
+		}
+	}
+	if closing {
+		lines = lines[:len(lines)-1]
+	}
+	var b strings.Builder
+	pieces := []piece{{0, s.line + 1, s.columnReset}}
+	for i, line := range lines {
+		n := commonPrefix(common, line)
+		if i != 0 {
+			b.WriteByte('\n')
+			pieces = append(pieces, piece{b.Len(), s.line + 1 + i, s.columnReset})
+		}
+		pieces[i].column += n
+		b.WriteString(line[n:])
+	}
+	start := s.Location()
+	s.pass(open + end + len(quotes))
+	return literal{b.String(), NewSpan(start, s.Location()), s.origin, pieces}, nil
+}
+
+// expect checks that content continues with prefix from the byte
+// offset n, which must be on the current line.
+func (s *script) expect(n int, prefix, lexeme string) Failure {
+	for i := 0; i < len(prefix); i++ {
+		if n+i == len(s.content) {
+			return s.fail(n+i, EndOfText, lexeme)
+		}
+		if s.content[n+i] != prefix[i] {
+			return s.fail(n+i, NotApplicable, lexeme)
+		}
+	}
+	return nil
+}
+
+// indentation returns the blanks line starts with.
+func indentation(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// commonPrefix returns the length of the prefix common to a and b.
+func commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// rawString writes value as a raw string, with one hash more than
+// the longest run of hashes that follows a quote in value.
+func rawString(value string) string {
+	hashes := 1
+	for i := 0; i < len(value); i++ {
+		if value[i] != '"' {
+			continue
+		}
+		n := len(value[i+1:]) - len(strings.TrimLeft(value[i+1:], "#"))
+		hashes = max(hashes, n+1)
+	}
+	closing := strings.Repeat("#", hashes)
+	return closing + `r"` + value + `"` + closing
+}
+
+// raw tells whether content starts with a raw string.
+func raw(content string) bool {
+	rest := strings.TrimLeft(content, "#")
+	return len(rest) != len(content) && strings.HasPrefix(rest, `r"`)
+}
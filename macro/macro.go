@@ -0,0 +1,200 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+// Package macro expands syntax-rules macros over forms read by peruse.
+//
+// A macro is defined by
+//
+//	(define-syntax swap!
+//	  (syntax-rules ()
+//	    ((_ a b) (let ((tmp a)) (set! a b) (set! b tmp)))))
+//
+// Rules support literals, _, ellipsis patterns (nested ones too),
+// (... ...) to escape the ellipsis in templates and a custom ellipsis
+// given before the literals as in R7RS.
+//
+// Every form of an expansion keeps the location it has in the source,
+// either in the use of the macro or in its template, and records the
+// use it was expanded from. Diagnostic uses that chain so that errors
+// inside macro output point at user code.
+//
+// Hygiene is by renaming: symbols a template introduces and binds with
+// lambda, let, let*, letrec, letrec* or a named let are renamed to
+// name.N, N being unique per expansion, so they cannot capture symbols
+// of the user. Free symbols of templates (if, set!, ...) are inserted
+// as they are and resolve where the macro is used.
+package macro
+
+import(
+	"fmt"
+	"strings"
+	"github.com/begopher/peruse"
+)
+
+// Form is a form produced by the expansion of a macro.
+type Form interface {
+	peruse.Form
+	// ExpandedFrom is the use of the macro the form came from.
+	ExpandedFrom() peruse.Form
+}
+
+type expanded struct {
+	peruse.Form
+	from peruse.Form
+}
+
+func (e expanded) ExpandedFrom() peruse.Form {
+	return e.from
+}
+
+// Chain returns the macro uses form was expanded from, the innermost
+// first. It is empty for forms that are not expanded.
+func Chain(form peruse.Form) []peruse.Form {
+	chain := []peruse.Form{}
+	for {
+		e, ok := form.(Form)
+		if !ok {
+			return chain
+		}
+		form = e.ExpandedFrom()
+		chain = append(chain, form)
+	}
+}
+
+// Diagnostic locates message at form and names the uses form was
+// expanded from, a use repeated in a row (recursion) is named once.
+func Diagnostic(form peruse.Form, message string) peruse.Diagnostic {
+	chain := Chain(form)
+	if len(chain) == 0 {
+		return peruse.NewDiagnostic(form.Span().Start(), message)
+	}
+	uses := []string{}
+	for _, use := range chain {
+		location := use.Span().Start().String()
+		if len(uses) == 0 || uses[len(uses)-1] != location {
+			uses = append(uses, location)
+		}
+	}
+	message = fmt.Sprintf("%s (expanded from %s)", message, strings.Join(uses, ", "))
+	return peruse.NewDiagnostic(form.Span().Start(), message)
+}
+
+// limit is the deepest a chain of expansions can get.
+const limit = 100
+
+type Expander interface {
+	// Define adds the macro of a (define-syntax keyword (syntax-rules ...)) form.
+	Define(form peruse.Form) error
+	// Expand expands the uses of macros in form until none is left,
+	// quoted forms are not expanded.
+	Expand(form peruse.Form) (peruse.Form, error)
+	// ExpandAll defines the macros of the define-syntax forms found
+	// in forms and expands the others.
+	ExpandAll(forms []peruse.Form) ([]peruse.Form, error)
+}
+
+func NewExpander() Expander {
+	return &expander{macros: map[string]rules{}}
+}
+
+type expander struct {
+	macros map[string]rules
+	// expansions counts the expansions, it numbers renamed symbols.
+	expansions int
+}
+
+func (e *expander) Define(form peruse.Form) error {
+	if peruse.Cyclic(form) {
+		return Diagnostic(form, "define-syntax contains itself")
+	}
+	forms := form.Forms()
+	if form.Kind() != peruse.List || len(forms) != 3 || !symbol(forms[0], "define-syntax") {
+		return Diagnostic(form, "expected (define-syntax keyword (syntax-rules ...))")
+	}
+	if !identifier(forms[1]) {
+		return Diagnostic(forms[1], "define-syntax expects a keyword")
+	}
+	r, err := compile(forms[1].Value(), forms[2])
+	if err != nil {
+		return err
+	}
+	e.macros[r.keyword] = r
+	return nil
+}
+
+func (e *expander) ExpandAll(forms []peruse.Form) ([]peruse.Form, error) {
+	result := []peruse.Form{}
+	for _, form := range forms {
+		if form.Kind() == peruse.List && len(form.Forms()) != 0 && symbol(form.Forms()[0], "define-syntax") {
+			if err := e.Define(form); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		form, err := e.Expand(form)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, form)
+	}
+	return result, nil
+}
+
+func (e *expander) Expand(form peruse.Form) (peruse.Form, error) {
+	if peruse.Cyclic(form) {
+		return nil, Diagnostic(form, "form contains itself and cannot be expanded")
+	}
+	form, _, err := e.expand(form, 0)
+	return form, err
+}
+
+// expand also tells whether form was changed.
+func (e *expander) expand(form peruse.Form, depth int) (peruse.Form, bool, error) {
+	forms := form.Forms()
+	if form.Kind() != peruse.List || len(forms) == 0 || symbol(forms[0], "quote") {
+		return form, false, nil
+	}
+	if identifier(forms[0]) {
+		if r, ok := e.macros[forms[0].Value()]; ok {
+			if depth == limit {
+				return nil, false, Diagnostic(form, fmt.Sprintf("expansion of %s is too deep", r.keyword))
+			}
+			e.expansions++
+			result, err := r.expand(form, e.expansions)
+			if err != nil {
+				return nil, false, err
+			}
+			result, _, err = e.expand(result, depth+1)
+			return result, true, err
+		}
+	}
+	children := make([]peruse.Form, len(forms))
+	changed := false
+	for i, child := range forms {
+		result, ok, err := e.expand(child, depth)
+		if err != nil {
+			return nil, false, err
+		}
+		children[i] = result
+		changed = changed || ok
+	}
+	if !changed {
+		return form, false, nil
+	}
+	list := peruse.NewList(children, form.Span())
+	if f, ok := form.(Form); ok {
+		return expanded{list, f.ExpandedFrom()}, true, nil
+	}
+	return list, true, nil
+}
+
+// identifier tells whether form names something, the reader
+// reads names such as set! or let* as atoms.
+func identifier(form peruse.Form) bool {
+	return form.Kind() == peruse.Symbol || form.Kind() == peruse.Atom
+}
+
+func symbol(form peruse.Form, value string) bool {
+	return identifier(form) && form.Value() == value
+}
@@ -0,0 +1,331 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package macro
+
+import(
+	"fmt"
+	"slices"
+	"github.com/begopher/peruse"
+)
+
+type rules struct {
+	keyword  string
+	ellipsis string
+	literals map[string]bool
+	rules    []rule
+}
+
+type rule struct {
+	pattern  peruse.Form
+	template peruse.Form
+}
+
+// binding is what a pattern variable matched, a variable under n
+// ellipses has a depth of n and one item per repetition.
+type binding struct {
+	form  peruse.Form
+	items []*binding
+	depth int
+}
+
+func compile(keyword string, spec peruse.Form) (rules, error) {
+	forms := spec.Forms()
+	if spec.Kind() != peruse.List || len(forms) == 0 || !symbol(forms[0], "syntax-rules") {
+		return rules{}, Diagnostic(spec, "expected (syntax-rules (literal ...) (pattern template) ...)")
+	}
+	r := rules{keyword: keyword, ellipsis: "...", literals: map[string]bool{}}
+	forms = forms[1:]
+	if len(forms) != 0 && identifier(forms[0]) {
+		r.ellipsis = forms[0].Value()
+		forms = forms[1:]
+	}
+	if len(forms) == 0 || forms[0].Kind() != peruse.List {
+		return rules{}, Diagnostic(spec, "syntax-rules expects a list of literals")
+	}
+	for _, literal := range forms[0].Forms() {
+		if !identifier(literal) {
+			return rules{}, Diagnostic(literal, "syntax-rules expects a literal")
+		}
+		r.literals[literal.Value()] = true
+	}
+	for _, form := range forms[1:] {
+		parts := form.Forms()
+		if form.Kind() != peruse.List || len(parts) != 2 || parts[0].Kind() != peruse.List || len(parts[0].Forms()) == 0 {
+			return rules{}, Diagnostic(form, "syntax-rules expects ((keyword pattern ...) template)")
+		}
+		if err := r.check(parts[0], 1); err != nil {
+			return rules{}, err
+		}
+		r.rules = append(r.rules, rule{parts[0], parts[1]})
+	}
+	return r, nil
+}
+
+// check reports the misplaced ellipses of pattern, first is the
+// index of the first form that can be repeated.
+func (r rules) check(pattern peruse.Form, first int) error {
+	found := false
+	for i, form := range pattern.Forms() {
+		if !symbol(form, r.ellipsis) {
+			if err := r.check(form, 0); err != nil {
+				return err
+			}
+			continue
+		}
+		if i <= first || symbol(pattern.Forms()[i-1], r.ellipsis) {
+			return Diagnostic(form, fmt.Sprintf("%s has to follow a pattern", r.ellipsis))
+		}
+		if found {
+			return Diagnostic(form, fmt.Sprintf("a list pattern has at most one %s", r.ellipsis))
+		}
+		found = true
+	}
+	return nil
+}
+
+func (r rules) expand(use peruse.Form, expansion int) (peruse.Form, error) {
+	for _, rule := range r.rules {
+		env := map[string]*binding{}
+		if !r.list(rule.pattern.Forms()[1:], use.Forms()[1:], env) {
+			continue
+		}
+		renames := map[string]string{}
+		r.binders(rule.template, env, renames, expansion)
+		return filler{r, use, renames}.template(rule.template, env, false)
+	}
+	return nil, Diagnostic(use, fmt.Sprintf("no rule of %s matches", r.keyword))
+}
+
+func (r rules) match(pattern, form peruse.Form, env map[string]*binding) bool {
+	if identifier(pattern) {
+		name := pattern.Value()
+		switch {
+		case r.literals[name]:
+			return symbol(form, name)
+		case name != "_":
+			env[name] = &binding{form: form}
+		}
+		return true
+	}
+	if pattern.Kind() != peruse.List {
+		return form.Kind() == pattern.Kind() && form.Value() == pattern.Value()
+	}
+	return form.Kind() == peruse.List && r.list(pattern.Forms(), form.Forms(), env)
+}
+
+func (r rules) list(patterns, forms []peruse.Form, env map[string]*binding) bool {
+	repeated := -1
+	for i, pattern := range patterns {
+		if symbol(pattern, r.ellipsis) {
+			repeated = i - 1
+		}
+	}
+	if repeated < 0 {
+		if len(patterns) != len(forms) {
+			return false
+		}
+		for i, pattern := range patterns {
+			if !r.match(pattern, forms[i], env) {
+				return false
+			}
+		}
+		return true
+	}
+	before, after := patterns[:repeated], patterns[repeated+2:]
+	tail := len(forms) - len(after)
+	if tail < len(before) {
+		return false
+	}
+	for i, pattern := range before {
+		if !r.match(pattern, forms[i], env) {
+			return false
+		}
+	}
+	for i, pattern := range after {
+		if !r.match(pattern, forms[tail+i], env) {
+			return false
+		}
+	}
+	variables := map[string]int{}
+	r.variables(patterns[repeated], 0, variables)
+	items := map[string][]*binding{}
+	for _, form := range forms[len(before):tail] {
+		matched := map[string]*binding{}
+		if !r.match(patterns[repeated], form, matched) {
+			return false
+		}
+		for name := range variables {
+			items[name] = append(items[name], matched[name])
+		}
+	}
+	for name, depth := range variables {
+		env[name] = &binding{items: items[name], depth: depth + 1}
+	}
+	return true
+}
+
+// variables collects the pattern variables of pattern with
+// the number of ellipses they are under.
+func (r rules) variables(pattern peruse.Form, depth int, variables map[string]int) {
+	if identifier(pattern) {
+		name := pattern.Value()
+		if !r.literals[name] && name != "_" && name != r.ellipsis {
+			variables[name] = depth
+		}
+		return
+	}
+	forms := pattern.Forms()
+	for i, form := range forms {
+		if i+1 < len(forms) && symbol(forms[i+1], r.ellipsis) {
+			r.variables(form, depth+1, variables)
+			continue
+		}
+		r.variables(form, depth, variables)
+	}
+}
+
+// binders finds the symbols template introduces and binds, and names
+// what they are renamed to.
+func (r rules) binders(template peruse.Form, env map[string]*binding, renames map[string]string, expansion int) {
+	forms := template.Forms()
+	bind := func(form peruse.Form) {
+		if _, ok := env[form.Value()]; identifier(form) && !ok && form.Value() != r.ellipsis {
+			renames[form.Value()] = fmt.Sprintf("%s.%d", form.Value(), expansion)
+		}
+	}
+	if template.Kind() == peruse.List && len(forms) > 1 && identifier(forms[0]) {
+		switch forms[0].Value() {
+		case "lambda":
+			bind(forms[1])
+			for _, parameter := range forms[1].Forms() {
+				bind(parameter)
+			}
+		case "let", "let*", "letrec", "letrec*":
+			bindings := forms[1]
+			if identifier(bindings) && len(forms) > 2 {
+				bind(bindings)
+				bindings = forms[2]
+			}
+			for _, binding := range bindings.Forms() {
+				if binding.Kind() == peruse.List && len(binding.Forms()) != 0 {
+					bind(binding.Forms()[0])
+				}
+			}
+		}
+	}
+	for _, form := range forms {
+		r.binders(form, env, renames, expansion)
+	}
+}
+
+// filler fills the template of a rule for one use of the macro.
+type filler struct {
+	rules
+	use     peruse.Form
+	renames map[string]string
+}
+
+func (x filler) wrap(form peruse.Form) peruse.Form {
+	return expanded{form, x.use}
+}
+
+func (x filler) template(template peruse.Form, env map[string]*binding, escaped bool) (peruse.Form, error) {
+	if identifier(template) {
+		name := template.Value()
+		if b, ok := env[name]; ok {
+			if b.depth != 0 {
+				return nil, Diagnostic(template, fmt.Sprintf("%s has to be followed by %s", name, x.ellipsis))
+			}
+			return x.wrap(b.form), nil
+		}
+		if renamed, ok := x.renames[name]; ok {
+			return x.wrap(peruse.NewAtom(template.Kind(), renamed, template.Span())), nil
+		}
+	}
+	if template.Kind() != peruse.List {
+		return x.wrap(template), nil
+	}
+	templates := template.Forms()
+	if !escaped && len(templates) == 2 && symbol(templates[0], x.ellipsis) {
+		return x.template(templates[1], env, true)
+	}
+	forms := []peruse.Form{}
+	for i := 0; i < len(templates); i++ {
+		depth := 0
+		for !escaped && i+depth+1 < len(templates) && symbol(templates[i+depth+1], x.ellipsis) {
+			depth++
+		}
+		if depth == 0 {
+			form, err := x.template(templates[i], env, escaped)
+			if err != nil {
+				return nil, err
+			}
+			forms = append(forms, form)
+			continue
+		}
+		repeated, err := x.repeat(templates[i], depth, env)
+		if err != nil {
+			return nil, err
+		}
+		forms = append(forms, repeated...)
+		i += depth
+	}
+	return x.wrap(peruse.NewList(forms, template.Span())), nil
+}
+
+// repeat fills template once for every item of the pattern
+// variables it holds, depth is the number of ellipses after it.
+func (x filler) repeat(template peruse.Form, depth int, env map[string]*binding) ([]peruse.Form, error) {
+	names := []string{}
+	x.repeated(template, env, &names)
+	if len(names) == 0 {
+		return nil, Diagnostic(template, fmt.Sprintf("no pattern variable to repeat before %s", x.ellipsis))
+	}
+	count := len(env[names[0]].items)
+	for _, name := range names[1:] {
+		if len(env[name].items) != count {
+			return nil, Diagnostic(template, fmt.Sprintf("%s and %s repeat a different number of times", names[0], name))
+		}
+	}
+	forms := []peruse.Form{}
+	for i := 0; i < count; i++ {
+		item := map[string]*binding{}
+		for name, b := range env {
+			item[name] = b
+		}
+		for _, name := range names {
+			item[name] = env[name].items[i]
+		}
+		if depth == 1 {
+			form, err := x.template(template, item, false)
+			if err != nil {
+				return nil, err
+			}
+			forms = append(forms, form)
+			continue
+		}
+		repeated, err := x.repeat(template, depth-1, item)
+		if err != nil {
+			return nil, err
+		}
+		forms = append(forms, repeated...)
+	}
+	return forms, nil
+}
+
+// repeated adds to names the pattern variables of template that
+// are still under an ellipsis.
+func (x filler) repeated(template peruse.Form, env map[string]*binding, names *[]string) {
+	if identifier(template) {
+		name := template.Value()
+		if b, ok := env[name]; ok && b.depth != 0 && !slices.Contains(*names, name) {
+			*names = append(*names, name)
+		}
+		return
+	}
+	for _, form := range template.Forms() {
+		x.repeated(form, env, names)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+// Option configures the script created by Script.
+type Option func(*script)
+
+// Keywords sets the keyword forms accepted by the script, the default
+// is ColonKeyword.
+func Keywords(syntax KeywordSyntax) Option {
+	return func(s *script) {
+		s.keywords = syntax
+	}
+}
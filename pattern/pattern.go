@@ -0,0 +1,334 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+// Package pattern matches forms against declarative patterns of
+// special forms, such as
+//
+//	(define <name:symbol> <body:expr>...)
+//	(let ((<symbol> <expr>)*) <body>...)
+//
+// A pattern is a list whose first symbol is the head of the form.
+// <kind> captures a form of that kind under the name kind, <name:kind>
+// under name, and <name> alone captures any form. The kinds are
+// symbol, keyword, integer, float, string, atom, list and expr (any
+// form). Other symbols, let* and 1+ included, are matched as they
+// are. A ..., * or + right after a capture, or on its own after any
+// element, repeats it zero or more, zero or more, or one or more
+// times, a list has at most one repeated element.
+//
+// Captures in repeated elements collect every match in order. Errors
+// name the head and what is expected, e.g. "define expects a name".
+package pattern
+
+import(
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"github.com/begopher/peruse"
+)
+
+type Pattern interface {
+	// Head is the symbol a matching form starts with.
+	Head() string
+	// Match reads the next form of text and matches it, on failure
+	// text is left untouched.
+	Match(text peruse.Text) (Match, error)
+	MatchForm(form peruse.Form) (Match, error)
+	String() string
+}
+
+// Match holds the forms captured by a pattern.
+type Match interface {
+	Form() peruse.Form
+	// Capture is the first form captured under name, or nil.
+	Capture(name string) peruse.Form
+	Captures(name string) []peruse.Form
+}
+
+func Compile(source string) (Pattern, error) {
+	form, err := peruse.Read(peruse.Script("pattern", source))
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("pattern: empty pattern")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if form.Kind() != peruse.List {
+		return nil, peruse.NewDiagnostic(form.Span().Start(), "pattern must be a list")
+	}
+	elements, err := compile(form)
+	if err != nil {
+		return nil, err
+	}
+	p := pattern{source: source, elements: elements}
+	if len(elements) != 0 && elements[0].literal != "" && elements[0].repeat == 0 {
+		p.head = elements[0].literal
+	}
+	return p, nil
+}
+
+func MustCompile(source string) Pattern {
+	p, err := Compile(source)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+var kinds = map[string]func(peruse.Kind) bool{
+	"expr":    func(peruse.Kind) bool { return true },
+	"form":    func(peruse.Kind) bool { return true },
+	"list":    func(k peruse.Kind) bool { return k == peruse.List },
+	"symbol":  func(k peruse.Kind) bool { return k == peruse.Symbol },
+	"keyword": func(k peruse.Kind) bool { return k == peruse.Keyword },
+	"integer": func(k peruse.Kind) bool { return k == peruse.Integer },
+	"float":   func(k peruse.Kind) bool { return k == peruse.Float },
+	"string":  func(k peruse.Kind) bool { return k == peruse.String || k == peruse.RawString },
+	"atom":    func(k peruse.Kind) bool { return k == peruse.Atom },
+}
+
+type element struct {
+	// literal is a symbol matched as it is.
+	literal string
+	name    string
+	kind    func(peruse.Kind) bool
+	// what is the description of the element used by errors.
+	what string
+	list []element
+	// repeat is the minimum number of repetitions plus one,
+	// zero for an element that is not repeated.
+	repeat int
+}
+
+func compile(list peruse.Form) ([]element, error) {
+	elements := []element{}
+	repeated := false
+	for _, form := range list.Forms() {
+		location := form.Span().Start()
+		if form.Kind() == peruse.List {
+			children, err := compile(form)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, element{list: children, what: "a list"})
+			continue
+		}
+		value, repeat := suffix(form.Value())
+		if value == "" && repeat != 0 && len(elements) == 0 && form.Value() != "..." {
+			// a leading + or * is a head, as in (+ <a> <b>)
+			value, repeat = form.Value(), 0
+		}
+		if value == "" && repeat != 0 {
+			if len(elements) == 0 || elements[len(elements)-1].repeat != 0 {
+				return nil, peruse.NewDiagnostic(location, fmt.Sprintf("%s repeats nothing", form.Value()))
+			}
+			elements[len(elements)-1].repeat = repeat
+		} else {
+			e, err := capture(value, location)
+			if err != nil {
+				return nil, err
+			}
+			e.repeat = repeat
+			elements = append(elements, e)
+		}
+		if repeat != 0 {
+			if repeated {
+				return nil, peruse.NewDiagnostic(location, "a list has at most one repeated element")
+			}
+			repeated = true
+		}
+	}
+	return elements, nil
+}
+
+// suffix strips the repetition suffix of value, which is either the
+// suffix alone or a capture followed by it.
+func suffix(value string) (string, int) {
+	for _, s := range []struct {
+		suffix string
+		repeat int
+	}{{"...", 1}, {"*", 1}, {"+", 2}} {
+		rest, found := strings.CutSuffix(value, s.suffix)
+		if found && (rest == "" || strings.HasSuffix(rest, ">")) {
+			return rest, s.repeat
+		}
+	}
+	return value, 0
+}
+
+func capture(value string, location peruse.Location) (element, error) {
+	if !strings.HasPrefix(value, "<") || !strings.HasSuffix(value, ">") {
+		return element{literal: value, what: value}, nil
+	}
+	inside := value[1 : len(value)-1]
+	name, kind, found := strings.Cut(inside, ":")
+	if !found {
+		if _, ok := kinds[name]; ok {
+			kind = name
+		} else {
+			kind = "expr"
+		}
+	}
+	match, ok := kinds[kind]
+	if name == "" || !ok {
+		return element{}, peruse.NewDiagnostic(location, fmt.Sprintf("invalid capture %s", value))
+	}
+	return element{name: name, kind: match, what: article(name)}, nil
+}
+
+func article(name string) string {
+	if strings.ContainsRune("aeiou", rune(name[0])) {
+		return "an " + name
+	}
+	return "a " + name
+}
+
+type pattern struct {
+	source   string
+	head     string
+	elements []element
+}
+
+func (p pattern) Head() string {
+	return p.head
+}
+
+func (p pattern) String() string {
+	return p.source
+}
+
+func (p pattern) Match(text peruse.Text) (Match, error) {
+	checkpoint := text.Checkpoint()
+	form, err := peruse.Read(text)
+	if errors.Is(err, io.EOF) {
+		return nil, peruse.NewDiagnostic(text.Location(), p.expected())
+	}
+	if err == nil {
+		var m Match
+		if m, err = p.MatchForm(form); err == nil {
+			return m, nil
+		}
+	}
+	text.Restore(checkpoint)
+	return nil, err
+}
+
+func (p pattern) MatchForm(form peruse.Form) (Match, error) {
+	forms := form.Forms()
+	if form.Kind() != peruse.List {
+		return nil, peruse.NewDiagnostic(form.Span().Start(), p.expected())
+	}
+	if p.head != "" && (len(forms) == 0 || !literal(forms[0], p.head)) {
+		return nil, peruse.NewDiagnostic(form.Span().Start(), p.expected())
+	}
+	m := match{form: form, captures: map[string][]peruse.Form{}}
+	if err := p.list(m, p.elements, form); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (p pattern) expected() string {
+	if p.head == "" {
+		return "expected a list"
+	}
+	return fmt.Sprintf("expected (%s ...)", p.head)
+}
+
+func (p pattern) fail(location peruse.Location, what string) error {
+	head := p.head
+	if head == "" {
+		head = "form"
+	}
+	return peruse.NewDiagnostic(location, fmt.Sprintf("%s expects %s", head, what))
+}
+
+// list matches the forms of list against elements.
+func (p pattern) list(m match, elements []element, list peruse.Form) error {
+	forms := list.Forms()
+	// closing is the location of the parenthesis closing list
+	end := list.Span().End()
+	closing := peruse.NewLocation(end.Origin(), end.Line(), end.Column()-1)
+	at := func(i int) peruse.Location {
+		if i < len(forms) {
+			return forms[i].Span().Start()
+		}
+		return closing
+	}
+	i := 0
+	for index, e := range elements {
+		if e.repeat == 0 {
+			if i == len(forms) {
+				return p.fail(closing, e.what)
+			}
+			if err := p.element(m, e, forms[i]); err != nil {
+				return err
+			}
+			i++
+			continue
+		}
+		count := max(len(forms)-i-(len(elements)-index-1), 0)
+		if count < e.repeat-1 {
+			return p.fail(at(i), e.what)
+		}
+		for ; count > 0; count-- {
+			if err := p.element(m, e, forms[i]); err != nil {
+				return err
+			}
+			i++
+		}
+	}
+	if i != len(forms) {
+		return p.fail(at(i), "')'")
+	}
+	return nil
+}
+
+func (p pattern) element(m match, e element, form peruse.Form) error {
+	location := form.Span().Start()
+	switch {
+	case e.list != nil:
+		if form.Kind() != peruse.List {
+			return p.fail(location, e.what)
+		}
+		return p.list(m, e.list, form)
+	case e.literal != "":
+		if !literal(form, e.literal) {
+			return p.fail(location, e.what)
+		}
+		return nil
+	}
+	if !e.kind(form.Kind()) {
+		return p.fail(location, e.what)
+	}
+	m.captures[e.name] = append(m.captures[e.name], form)
+	return nil
+}
+
+type match struct {
+	form     peruse.Form
+	captures map[string][]peruse.Form
+}
+
+func (m match) Form() peruse.Form {
+	return m.form
+}
+
+func (m match) Capture(name string) peruse.Form {
+	if forms := m.captures[name]; len(forms) != 0 {
+		return forms[0]
+	}
+	return nil
+}
+
+func (m match) Captures(name string) []peruse.Form {
+	return m.captures[name]
+}
+
+// literal tells whether form is written as value, a list or a string
+// is never a literal element (e.g. +, set! and define are).
+func literal(form peruse.Form, value string) bool {
+	return form.Kind() != peruse.List && form.Kind() != peruse.String && form.Kind() != peruse.RawString && form.Value() == value
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"sort"
+	"strings"
+)
+
+// Segment is one part of a qualified name such as ns/name.
+type Segment interface {
+	Value() string
+	// Separator is the separator written before the segment, it
+	// is empty for the first segment.
+	Separator() string
+	Span() Span
+}
+
+type segment struct {
+	value     string
+	separator string
+	span      Span
+}
+
+func (s segment) Value() string {
+	return s.value
+}
+
+func (s segment) Separator() string {
+	return s.separator
+}
+
+func (s segment) Span() Span {
+	return s.span
+}
+
+// EatQualifiedSymbol eats symbols joined by any of separators (e.g.
+// "/", "::", "."), the longest separator is matched first. A symbol
+// without separators is returned as a single segment. Every segment
+// must be a symbol, otherwise nothing is eaten and the failure points
+// at the bad segment.
+func (s *script) EatQualifiedSymbol(separators ...string) ([]Segment, error) {
+	const lexeme = "qualified symbol"
+	if err := s.starts(lexeme, func(r rune) bool { return !delimiter(r) }); err != nil {
+		return nil, err
+	}
+	separators = append([]string{}, separators...)
+	sort.SliceStable(separators, func(i, j int) bool {
+		return len(separators[i]) > len(separators[j])
+	})
+	token := s.until()
+	type part struct {
+		separator string
+		from, to  int
+	}
+	parts := []part{{from: 0}}
+	for i := 0; i < len(token); {
+		separator := ""
+		for _, candidate := range separators {
+			if candidate != "" && strings.HasPrefix(token[i:], candidate) {
+				separator = candidate
+				break
+			}
+		}
+		if separator == "" {
+			i++
+			continue
+		}
+		parts[len(parts)-1].to = i
+		i += len(separator)
+		parts = append(parts, part{separator: separator, from: i})
+	}
+	parts[len(parts)-1].to = len(token)
+	segments := make([]Segment, len(parts))
+	for i, p := range parts {
+		if p.from == p.to {
+			return nil, s.fail(p.from, EmptyPart, lexeme)
+		}
+		value := token[p.from:p.to]
+		if !s.IsSymbol(value) {
+			return nil, s.name(lexeme, p.from, p.to, true)
+		}
+		segments[i] = segment{s.identifier(value), p.separator, NewSpan(s.at(p.from), s.at(p.to))}
+	}
+	s.skip(len(token))
+	return segments, nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Quantity is a number written with a unit (e.g. 30s, 512MiB, 90%).
+type Quantity interface {
+	// Number is the numeric part as it is written.
+	Number() string
+	Unit() string
+	// Value is the number converted by its unit (e.g. a time.Duration).
+	Value() any
+	Span() Span
+	String() string
+}
+
+type quantity struct {
+	number string
+	unit   string
+	value  any
+	span   Span
+}
+
+func (q quantity) Number() string {
+	return q.number
+}
+
+func (q quantity) Unit() string {
+	return q.unit
+}
+
+func (q quantity) Value() any {
+	return q.value
+}
+
+func (q quantity) Span() Span {
+	return q.span
+}
+
+func (q quantity) String() string {
+	return q.number + q.unit
+}
+
+// Unit converts the numeric part of a quantity, an optional sign
+// followed by digits and an optional fraction, to its value.
+type Unit func(number string) (any, error)
+
+// Units maps the suffixes of quantities to their unit.
+type Units map[string]Unit
+
+// WithUnits sets the units known by EatQuantity, when many tables
+// have the same suffix the last one wins. The reader reads numbers
+// with a suffix as quantities once units are set.
+func WithUnits(tables ...Units) Option {
+	return func(s *script) {
+		if s.units == nil {
+			s.units = Units{}
+		}
+		for _, units := range tables {
+			for suffix, unit := range units {
+				s.units[suffix] = unit
+			}
+		}
+	}
+}
+
+// DurationUnits converts ns, us, µs, ms, s, m and h to a time.Duration.
+func DurationUnits() Units {
+	units := Units{}
+	for _, suffix := range []string{"ns", "us", "µs", "ms", "s", "m", "h"} {
+		units[suffix] = func(number string) (any, error) {
+			return time.ParseDuration(number + suffix)
+		}
+	}
+	return units
+}
+
+// ByteUnits converts B and the decimal (KB, MB, GB, TB) and binary
+// (KiB, MiB, GiB, TiB) multiples of a byte to an int64 number of bytes,
+// a negative size is refused.
+func ByteUnits() Units {
+	units := Units{"B": bytes(1)}
+	decimal, binary := int64(1), int64(1)
+	for _, prefix := range []string{"K", "M", "G", "T"} {
+		decimal, binary = decimal*1000, binary*1024
+		units[prefix+"B"] = bytes(decimal)
+		units[prefix+"iB"] = bytes(binary)
+	}
+	return units
+}
+
+// PercentUnits converts % to a float64 fraction, 90% is 0.9.
+func PercentUnits() Units {
+	return Units{"%": func(number string) (any, error) {
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return nil, err
+		}
+		return value / 100, nil
+	}}
+}
+
+// bytes scales number by size exactly, the digits of number are an
+// integer and the fraction divides it by a power of ten.
+func bytes(size int64) Unit {
+	return func(number string) (any, error) {
+		integer, fraction, _ := strings.Cut(number, ".")
+		value, ok := new(big.Int).SetString(integer+fraction, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", number)
+		}
+		if value.Sign() < 0 {
+			return nil, errors.New("negative size")
+		}
+		value.Mul(value, big.NewInt(size))
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(fraction))), nil)
+		value, remainder := value.QuoRem(value, divisor, new(big.Int))
+		if remainder.Sign() != 0 {
+			return nil, errors.New("fraction of a byte")
+		}
+		if !value.IsInt64() {
+			return nil, errors.New("too many bytes")
+		}
+		return value.Int64(), nil
+	}
+}
+
+// EatQuantity eats a number followed by a suffix from the units of
+// the script, a number without a suffix is not applicable. A suffix
+// of letters and % which is not a known unit fails with the
+// UnknownUnit reason, any other suffix is not applicable. A number
+// its unit refuses is reported where the number starts.
+func (s *script) EatQuantity() (Quantity, error) {
+	const lexeme = "quantity"
+	token := atom(s.content)
+	n := numberLength(token)
+	switch {
+	case len(s.content) == 0:
+		return nil, s.fail(0, EndOfText, lexeme)
+	case len(s.units) == 0 || n == 0:
+		return nil, s.fail(0, NotApplicable, lexeme)
+	case n == len(token):
+		return nil, s.fail(n, NotApplicable, lexeme)
+	}
+	number, suffix := token[:n], token[n:]
+	unit, ok := s.units[suffix]
+	if !ok && !unitLike(suffix) {
+		return nil, s.fail(n, NotApplicable, lexeme)
+	}
+	if !ok {
+		return nil, NewFailure(s.at(n), UnknownUnit, fmt.Sprintf("%q", suffix), 0)
+	}
+	value, err := unit(number)
+	if err != nil {
+		return nil, NewDiagnostic(s.Location(), fmt.Sprintf("invalid %s %s: %s", lexeme, token, err))
+	}
+	start := s.Location()
+	s.skip(len(token))
+	return quantity{number, suffix, value, NewSpan(start, s.Location())}, nil
+}
+
+// unitLike tells whether suffix is made of letters and %, a number
+// followed by anything else (e.g. 1+) is not a quantity.
+func unitLike(suffix string) bool {
+	for _, r := range suffix {
+		if !unicode.IsLetter(r) && r != '%' {
+			return false
+		}
+	}
+	return true
+}
+
+// numberLength returns the length of the number content starts with,
+// an optional sign followed by digits and an optional fraction.
+func numberLength(content string) int {
+	digit := func(i int) bool { return i < len(content) && '0' <= content[i] && content[i] <= '9' }
+	i := 0
+	if i < len(content) && (content[i] == '-' || content[i] == '+') {
+		i++
+	}
+	if !digit(i) {
+		return 0
+	}
+	for digit(i) {
+		i++
+	}
+	if i < len(content) && content[i] == '.' && digit(i+1) {
+		for i++; digit(i); i++ {
+		}
+	}
+	return i
+}
@@ -0,0 +1,306 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Read skips leading spaces and reads the next form from text,
+// io.EOF is returned when nothing is left.
+func Read(text Text) (Form, error) {
+	r := reader{text: text, base: text.Length()}
+	space(text)
+	if text.Empty() {
+		return nil, io.EOF
+	}
+	return r.read()
+}
+
+// ReadRecover reads all forms of text and keeps going after errors.
+// A form with an error is dropped and reading resumes at the next
+// top-level form, for that a parenthesis at the start of a line is
+// always taken as the start of a top-level form. Reading stops after
+// limit diagnostics, a limit less than one collects all of them.
+func ReadRecover(text Text, limit int) ([]Form, []Diagnostic) {
+	r := reader{text: text, base: text.Length(), recover: true}
+	forms, _, diagnostics, _ := r.recoverAll(limit)
+	return forms, diagnostics
+}
+
+// ReadAll reads the forms of text up to its end and checks ctx while
+// reading. When ctx is done, the forms read so far are returned with
+// an error that wraps ctx.Err() and is located where text was left:
+// the start of the top-level form being read, so a later call resumes
+// from there.
+func ReadAll(ctx context.Context, text Text) ([]Form, error) {
+	r := reader{text: text, base: text.Length(), ctx: ctx, reads: new(int)}
+	forms := []Form{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return forms, interrupted{text.Location(), err}
+		}
+		space(text)
+		if text.Empty() {
+			return forms, nil
+		}
+		checkpoint := text.Checkpoint()
+		form, err := r.read()
+		if errors.Is(err, io.EOF) {
+			return forms, nil
+		}
+		if err != nil {
+			var i interrupted
+			if errors.As(err, &i) {
+				text.Restore(checkpoint)
+				return forms, interrupted{text.Location(), i.err}
+			}
+			return forms, err
+		}
+		forms = append(forms, form)
+	}
+}
+
+// interval is the number of forms read between two checks of the
+// context of a reader.
+const interval = 256
+
+// interrupted is the Diagnostic of a read stopped by its context.
+type interrupted struct {
+	location Location
+	err      error
+}
+
+func (i interrupted) Location() Location {
+	return i.location
+}
+
+func (i interrupted) Message() string {
+	return i.err.Error()
+}
+
+func (i interrupted) Error() string {
+	return fmt.Sprintf("%s: %s", i.location, i.err)
+}
+
+func (i interrupted) Unwrap() error {
+	return i.err
+}
+
+// space skips the spaces and comments text starts with.
+func space(text Text) {
+	for text.EatSpaces(); text.EatComment() != ""; text.EatSpaces() {
+	}
+}
+
+type reader struct {
+	text Text
+	// base is the length of the content the offsets
+	// of the forms are measured from.
+	base int
+	// recover makes a parenthesis at the start of a line
+	// end the lists that are still open.
+	recover bool
+	// depth is the number of lists the reader is in.
+	depth int
+	// ctx is checked every interval forms, reads counts them.
+	ctx   context.Context
+	reads *int
+	// labels are the datum labels of the top-level form being
+	// read, it is nil unless the text reads datum labels.
+	labels map[string]*label
+	// features are present for reader conditionals, which are
+	// only read when it is not nil. skipped reports the regions
+	// left out unless they are inside a region being skipped.
+	features map[string]bool
+	skipped  func(Span)
+	// skipping is set while a form left out by a reader conditional
+	// is read, it is not counted and its datum labels are ignored.
+	skipping bool
+}
+
+// count counts a form that starts at location unless it is skipped.
+func (r reader) count(location Location) error {
+	if r.skipping {
+		return nil
+	}
+	return count(r.text, location)
+}
+
+// topLevel tells whether the next form is expected to be a top-level one.
+func (r reader) topLevel() bool {
+	return r.text.Column() == 1 && r.text.BeginWith("(")
+}
+
+func (r reader) offset() int {
+	return r.base - r.text.Length()
+}
+
+// readAll reads the forms of the text and the extent of each of them.
+func (r reader) readAll() ([]Form, []extent, error) {
+	forms, extents := []Form{}, []extent{}
+	for {
+		space(r.text)
+		if r.text.Empty() {
+			return forms, extents, nil
+		}
+		start := r.offset()
+		form, err := r.read()
+		if errors.Is(err, io.EOF) {
+			return forms, extents, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		forms = append(forms, form)
+		extents = append(extents, extent{start, r.offset(), r.text.Location()})
+	}
+}
+
+// recoverAll reads as ReadRecover does and records the extent of each
+// form. The error is returned only when the context of r is done, it
+// is located at the start of the top-level form being read.
+func (r reader) recoverAll(limit int) ([]Form, []extent, []Diagnostic, error) {
+	text := r.text
+	forms, extents, diagnostics := []Form{}, []extent{}, []Diagnostic{}
+	for {
+		if r.ctx != nil && r.ctx.Err() != nil {
+			return forms, extents, diagnostics, interrupted{text.Location(), r.ctx.Err()}
+		}
+		space(text)
+		if text.Empty() {
+			break
+		}
+		start, checkpoint := text.Length(), text.Checkpoint()
+		offset := r.offset()
+		form, err := r.read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err == nil {
+			forms = append(forms, form)
+			extents = append(extents, extent{offset, r.offset(), text.Location()})
+			continue
+		}
+		var i interrupted
+		if errors.As(err, &i) {
+			text.Restore(checkpoint)
+			return forms, extents, diagnostics, interrupted{text.Location(), i.err}
+		}
+		var diagnostic Diagnostic
+		if !errors.As(err, &diagnostic) {
+			diagnostic = NewDiagnostic(text.Location(), err.Error())
+		}
+		diagnostics = append(diagnostics, diagnostic)
+		if len(diagnostics) == limit {
+			break
+		}
+		if text.BeginWith(")") {
+			text.SkipToDelimiter()
+			continue
+		}
+		if text.Length() == start || !r.topLevel() {
+			text.SkipToNextTopLevel()
+		}
+	}
+	return forms, extents, diagnostics, nil
+}
+
+func (r reader) read() (Form, error) {
+	if r.depth == 0 && labelling(r.text) {
+		r.labels = map[string]*label{}
+	}
+	if s, ok := r.text.(*script); ok {
+		r.features, r.skipped = s.features, s.skipped
+	}
+	return r.datum()
+}
+
+func (r reader) datum() (Form, error) {
+	text := r.text
+	start, offset := text.Location(), r.offset()
+	if err := input(text); err != nil {
+		return nil, err
+	}
+	if r.features != nil {
+		if marker := conditional(text.Remain()); marker != "" {
+			return r.conditional(marker, start)
+		}
+	}
+	if err := r.count(start); err != nil {
+		return nil, err
+	}
+	if r.ctx != nil {
+		if *r.reads++; *r.reads%interval == 0 && r.ctx.Err() != nil {
+			return nil, interrupted{start, r.ctx.Err()}
+		}
+	}
+	if r.labels != nil {
+		if name := datumLabel(text.Remain()); name != "" {
+			return r.label(name, start, offset)
+		}
+	}
+	if text.BeginWith("(") {
+		return r.list()
+	}
+	kind, value, err := lexeme(text)
+	if err != nil {
+		return nil, err
+	}
+	return r.atom(kind, value, start, offset), nil
+}
+
+func (r reader) list() (Form, error) {
+	text := r.text
+	start, offset := text.Location(), r.offset()
+	r.depth++
+	if err := nest(text, r.depth, start); err != nil {
+		return nil, err
+	}
+	text.Eat("(")
+	forms := []Form{}
+	for {
+		space(text)
+		if text.Empty() {
+			return nil, NewDiagnostic(start, "unclosed '('")
+		}
+		if text.Eat(")") {
+			break
+		}
+		if r.recover && r.topLevel() {
+			return nil, NewDiagnostic(start, "unclosed '('")
+		}
+		form, err := r.read()
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := form.(spliced); ok {
+			forms = append(forms, s.forms...)
+			continue
+		}
+		forms = append(forms, form)
+	}
+	return form{
+		kind:  List,
+		forms: forms,
+		span:  NewSpan(start, text.Location()),
+		start: offset,
+		end:   r.offset(),
+	}, nil
+}
+
+func (r reader) atom(kind Kind, value string, start Location, offset int) Form {
+	return form{
+		kind:  kind,
+		value: value,
+		span:  NewSpan(start, r.text.Location()),
+		start: offset,
+		end:   r.offset(),
+	}
+}
+
@@ -0,0 +1,76 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"strings"
+	"github.com/begopher/peruse/internal/numeric"
+)
+
+// Scanner recognises a lexeme, Scan returns the prefix of content
+// that forms the lexeme or an empty string.
+type Scanner interface {
+	Scan(content string) string
+}
+
+type ScannerFunc func(content string) string
+
+func (f ScannerFunc) Scan(content string) string {
+	return f(content)
+}
+
+// Scanners returns the result of the first of many that recognises
+// a lexeme.
+func Scanners(many ...Scanner) Scanner {
+	numbers := make([]numeric.Number, len(many))
+	for i, scanner := range many {
+		numbers[i] = scanner
+	}
+	return numeric.Numbers(numbers...)
+}
+
+// IntegerScanner recognises the integers eaten by EatInteger.
+func IntegerScanner() Scanner {
+	return integer
+}
+
+// FloatScanner recognises the floats eaten by EatFloat.
+func FloatScanner() Scanner {
+	return float
+}
+
+// Custom is the first kind free for lexemes registered with the
+// Lexeme option.
+const Custom Kind = 100
+
+// Lexeme registers scanner as a lexeme of kind, registered lexemes are
+// eaten by EatLexeme and tried by the reader before the built-in ones.
+func Lexeme(kind Kind, scanner Scanner) Option {
+	return func(s *script) {
+		s.lexemes = append(s.lexemes, custom{kind, scanner})
+	}
+}
+
+type custom struct {
+	kind    Kind
+	scanner Scanner
+}
+
+func (s *script) EatWith(scanner Scanner) string {
+	result := scanner.Scan(s.content)
+	if result == "" || !strings.HasPrefix(s.content, result) {
+		return ""
+	}
+	return s.pass(len(result))
+}
+
+func (s *script) EatLexeme() (Kind, string) {
+	for _, l := range s.lexemes {
+		if result := s.EatWith(l.scanner); result != "" {
+			return l.kind, result
+		}
+	}
+	return 0, ""
+}
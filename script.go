@@ -6,6 +6,7 @@ package peruse
 
 import(
 	"unicode"
+	"unicode/utf8"
 	"strings"
 	"github.com/begopher/peruse/internal/numeric"
 	"github.com/begopher/peruse/internal/numeric/ints"
@@ -15,8 +16,8 @@ import(
 var integer = numeric.Numbers(ints.Signed(), ints.Unsigned())
 var float = numeric.Numbers(floats.Signed(), floats.Unsigned())
 
-func Script(origin, content string) Text {
-	return &script{
+func Script(origin, content string, options ...Option) Text {
+	s := &script{
 		origin: origin,
 		integer: integer,
 		float: float,
@@ -24,10 +25,19 @@ func Script(origin, content string) Text {
 		lineReset: 1,
 		column: 1,
 		columnReset: 1,
-		content: []rune(content),
+		content: content,
+		length: utf8.RuneCountInString(content),
+		keywords: ColonKeyword,
+		size: len(content),
 	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
 }
 
+// script reads its content in place, every string it returns is a
+// substring of the content given to Script.
 type script struct {
 	origin string
 	integer numeric.Number
@@ -36,7 +46,22 @@ type script struct {
 	lineReset int
 	column int
 	columnReset int
-	content []rune
+	content string
+	// length is the number of runes left in content
+	length int
+	keywords KeywordSyntax
+	lexemes []custom
+	normalization Normalization
+	readCase Case
+	limits Limits
+	units Units
+	labels bool
+	features map[string]bool
+	skipped func(Span)
+	// size is the size of the content given to Script and forms
+	// the number of forms read, both are checked against limits.
+	size int
+	forms int
 }
 
 func (s *script) Origin() string {
@@ -56,7 +81,7 @@ func (s *script) Location() Location{
 }
 
 func (s *script) Length() int {
-	return len(s.content)
+	return s.length
 }
 
 func (s *script) Empty() bool {
@@ -65,39 +90,58 @@ func (s *script) Empty() bool {
 
 
 func (s *script) Remain() string {
-	return string(s.content)
+	return s.content
+}
+
+// skip consumes the first n bytes of content, which are known
+// not to contain a new line.
+func (s *script) skip(n int) string {
+	result := s.content[:n]
+	count := utf8.RuneCountInString(result)
+	s.content = s.content[n:]
+	s.length -= count
+	s.column += count
+	return result
 }
 
 func (s *script) EatSpaces() {
-	for _, r := range s.content {
+	for len(s.content) != 0 {
+		r, size := utf8.DecodeRuneInString(s.content)
 		if !unicode.IsSpace(r) {
 			break
 		}
+		s.content = s.content[size:]
+		s.length--
 		if '\n' == r {
 			s.line++
 			s.column = s.columnReset
-			s.content = s.content[1:]
 			continue
 		}
 		if ' ' == r || '\t' == r {
 			s.column++
-			s.content = s.content[1:]		
 			continue
 		}
-		s.content = s.content[1:]
-		continue
 	}	
 }
 
+// EatComment eats a comment, from a semicolon up to the end of the
+// line, and returns it with its semicolon.
+func (s *script) EatComment() string {
+	if len(s.content) == 0 || s.content[0] != ';' {
+		return ""
+	}
+	end := strings.IndexByte(s.content, '\n')
+	if end < 0 {
+		end = len(s.content)
+	}
+	return s.skip(end)
+}
+
 func (s *script) BeginWith(prefix string) bool {
 	if prefix == "" {
 		return false
 	}
-	sub := []rune(prefix)
-	if len(sub) > len(s.content) {
-		return false
-	}
-	return string(s.content[:len(sub)]) == prefix
+	return strings.HasPrefix(s.content, prefix)
 }
 
 
@@ -106,7 +150,9 @@ func (s *script) Eat(prefix string) bool {
 		return false
 	}
 	column, line := s.column, s.line
+	count := 0
 	for _, r := range prefix {
+		count++
 		if r == '\n' {
 			line++
 			column = s.columnReset
@@ -114,31 +160,39 @@ func (s *script) Eat(prefix string) bool {
 		}
 		column++
 	}
-	sub := []rune(prefix)
-	s.content = s.content[len(sub):]
+	s.content = s.content[len(prefix):]
+	s.length -= count
 	s.column = column
 	s.line = line
 	return true	
 }
 
 func (s *script) EatFunctionName(name string) bool {
-	if s.Eat("("+name+" ") {
-		return true
+	if !s.BeginWith("(") {
+		return false
 	}
-	if s.Eat("("+name+"\n") {
-		return true
+	rest := s.content[1:]
+	end := strings.IndexFunc(rest, delimiter)
+	if end < 0 {
+		end = len(rest)
 	}
-	if s.BeginWith("("+name+")") && s.Eat("("+name) {
-		return true
+	matches := rest[:end] == name
+	if s.policy() {
+		matches = end != 0 && s.identifier(rest[:end]) == s.identifier(name)
 	}
-	if len("("+name) == s.Length() && s.Eat("("+name) {
+	if !matches {
+		return false
+	}
+	if r, size := utf8.DecodeRuneInString(rest[end:]); unicode.IsSpace(r) {
+		s.pass(end + 1 + size)
 		return true
 	}
-	return false
+	s.skip(end + 1)
+	return true
 }
 
 func (s *script) EatString() (string, bool) {
-	if s.Length() == 0 {
+	if len(s.content) == 0 {
 		return "", false
 	}
 	if s.content[0] != '"' {
@@ -149,19 +203,21 @@ func (s *script) EatString() (string, bool) {
 	col, offset := 2, 2	// for first(") and last(")
 	buffer := s.content[1:]
 	closed := false
-	for i := 0; i<len(buffer); i++ {
-		r := buffer[i]
+	end := 0
+	for end < len(buffer) {
+		r, size := utf8.DecodeRuneInString(buffer[end:])
 		if r == '\n' {
 			offset++
 			line++
 			col = s.columnReset			
+			end += size
 			continue
 		}
-		if r == '\\' && i < len(buffer) && buffer[i+1] == '"' {
+		if r == '\\' && end+1 < len(buffer) && buffer[end+1] == '"' {
 			offset+=2
 			col+=2
 			//col+=1
-			i++			
+			end += 2
 			continue
 		}
 		if r == '"' {
@@ -172,45 +228,54 @@ func (s *script) EatString() (string, bool) {
 		}
 		offset++
 		col++
+		end += size
 	}
 	if !closed {
 		return "", false
 	}
-	result := s.content[1:offset-1]
-	s.content = s.content[offset:]	
+	result := buffer[:end]
+	s.content = buffer[end+1:]
+	s.length -= offset
 	if line == 0 {		
 		s.column += col
 	} else {
 		s.line += line
 		s.column = col
 	}
-	return string(result), true
+	return result, true
 }
 
 func (s *script) EatWord() string {
+	if s.policy() {
+		return s.identify(func(t *script) []string { return []string{t.EatWord()} })[0]
+	}
 	if len(s.content) == 0 {
 		return ""
 	}
-	if !unicode.IsLetter(s.content[0]) {
+	if r, _ := utf8.DecodeRuneInString(s.content); !unicode.IsLetter(r) {
 		return ""
 	}
 	offset := 0
 	for _, r := range s.content {
-		if r == ' ' || r == ')' || r =='\n' {
+		if delimiter(r) {
 			break
 		}
 		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
 			return ""
 		}					
-		offset++
+		offset += utf8.RuneLen(r)
 	}
-	s.column += offset
-	result := s.content[:offset]
-	s.content = s.content[offset:]
-	return string(result)
+	return s.skip(offset)
 }
 
 func (s *script) EatPrefixedWord(prefix string) (string, string) {
+	if s.policy() && prefix != "" {
+		values := s.identify(func(t *script) []string {
+			word, prefixed := t.EatPrefixedWord(prefix)
+			return []string{prefixed, word}
+		})
+		return values[1], values[0]
+	}
 	if len(prefix) == 0 {
 		result := s.EatWord()
 		return result, result
@@ -218,40 +283,45 @@ func (s *script) EatPrefixedWord(prefix string) (string, string) {
 	if len(s.content) <= len(prefix) { 
 		return "", ""
 	}
-	if string(s.content[:len(prefix)]) != prefix {
+	if !strings.HasPrefix(s.content, prefix) {
 		return "", ""
 	}
 	content := s.content[len(prefix):]
-	if !unicode.IsLetter(content[0]) {
+	if r, _ := utf8.DecodeRuneInString(content); !unicode.IsLetter(r) {
 		return "", ""
 	}
-	offset := len(prefix) // +1 maybe ?
+	offset := len(prefix)
 	for _, r := range content {
-		if r == ' ' || r == ')' || r =='\n' {
+		if delimiter(r) {
 			break
 		}
 		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
 			return "", ""
 		}					
-		offset++
+		offset += utf8.RuneLen(r)
 	}
-	result := s.content[:offset]
-	s.column += offset	
-	s.content = s.content[offset:]
-	return string(result[len(prefix):]), string(result)
+	result := s.skip(offset)
+	return result[len(prefix):], result
 }
 
 func (s *script) EatWords() (string, string) {
+	if s.policy() {
+		values := s.identify(func(t *script) []string {
+			first, second := t.EatWords()
+			return []string{first, second}
+		})
+		return values[0], values[1]
+	}
 	if len(s.content) == 0 {
 		return "", ""
 	}
-	if !unicode.IsLetter(s.content[0]) {
+	if r, _ := utf8.DecodeRuneInString(s.content); !unicode.IsLetter(r) {
 		return "", ""
 	}
 	offset := 0
 	colons := 0
 	for _, r := range s.content {
-		if r == ' ' || r == ')' || r =='\n' {
+		if delimiter(r) {
 			break
 		}
 		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ':' {
@@ -260,33 +330,30 @@ func (s *script) EatWords() (string, string) {
 		if r == ':' {
 			colons++
 		}	
-		offset++
+		offset += utf8.RuneLen(r)
 	}
 	result := s.content[:offset]
-	words := strings.Split(string(result), ":")
-	if len(words) != 2 {
+	first, second, found := strings.Cut(result, ":")
+	if !found || colons != 1 {
 		return "", ""
 	}
-	first  := words[0]
-	second := words[1]
 	if !s.IsWord(first) || !s.IsWord(second) {
 		//if len(letters) == 0 || unicode.IsDigit(letters[0]) {
 		return "", ""
 	}
-	s.column += offset
-	s.content = s.content[offset:]
-	return words[0], words[1]
+	s.skip(offset)
+	return first, second
 }
 
 func (s *script) IsWord(value string) bool {
-	runes := []rune(value)
-	if len(runes) == 0 {
+	value = s.normal(value)
+	if len(value) == 0 {
 		return false
 	}
-	if !unicode.IsLetter(runes[0]) {
+	if r, _ := utf8.DecodeRuneInString(value); !unicode.IsLetter(r) {
 		return false
 	}	
-	for _,  r := range runes {
+	for _,  r := range value {
 		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
 			return false
 		}
@@ -295,33 +362,39 @@ func (s *script) IsWord(value string) bool {
 }
 
 func (s *script) EatSymbol() string {
+	if s.policy() {
+		return s.identify(func(t *script) []string { return []string{t.EatSymbol()} })[0]
+	}
 	if len(s.content) == 0 {
 		return ""
 	}
-	if !unicode.IsLetter(s.content[0]) {
+	if r, _ := utf8.DecodeRuneInString(s.content); !unicode.IsLetter(r) {
 		return ""
 	}
 	offset := 0
 	for _, r := range s.content {
-		if r == ' ' || r == ')' || r =='\n' {
+		if delimiter(r) {
 			break
 		}
 		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
 			return ""
 		}					
-		offset++
+		offset += utf8.RuneLen(r)
 	}
-	result := s.content[:offset]
-	last := result[len(result)-1]
-	if  last == '-' {
+	if  s.content[offset-1] == '-' {
 		return ""
 	}
-	s.column += offset
-	s.content = s.content[offset:]
-	return string(result)
+	return s.skip(offset)
 }
 
 func (s *script) EatPrefixedSymbol(prefix string) (string, string) {
+	if s.policy() && prefix != "" {
+		values := s.identify(func(t *script) []string {
+			symbol, prefixed := t.EatPrefixedSymbol(prefix)
+			return []string{prefixed, symbol}
+		})
+		return values[1], values[0]
+	}
 	if len(prefix) == 0 {
 		result := s.EatSymbol()
 		return result, result
@@ -329,45 +402,48 @@ func (s *script) EatPrefixedSymbol(prefix string) (string, string) {
 	if len(s.content) <= len(prefix) { 
 		return "", ""
 	}
-	if string(s.content[:len(prefix)]) != prefix {
+	if !strings.HasPrefix(s.content, prefix) {
 		return "", ""
 	}
 	content := s.content[len(prefix):]	
-	if !unicode.IsLetter(content[0]) {
+	if r, _ := utf8.DecodeRuneInString(content); !unicode.IsLetter(r) {
 		return "", ""
 	}
 	offset := len(prefix) 
 	for _, r := range content {
-		if r == ' ' || r == ')' || r =='\n' {
+		if delimiter(r) {
 			break
 		}
 		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
 			return "", ""
 		}					
-		offset++
+		offset += utf8.RuneLen(r)
 	}
-	result := s.content[:offset]
-	last := result[len(result)-1]
-	if  last == '-' {
+	if  s.content[offset-1] == '-' {
 		return "", ""
 	}
-	s.column += offset
-	s.content = s.content[offset:]
-	return string(result[len(prefix):]), string(result)
-	//	return string(result)	
+	result := s.skip(offset)
+	return result[len(prefix):], result
 }
 
 func (s *script) EatSymbols() (string, string) {
+	if s.policy() {
+		values := s.identify(func(t *script) []string {
+			first, second := t.EatSymbols()
+			return []string{first, second}
+		})
+		return values[0], values[1]
+	}
 	if len(s.content) == 0 {
 		return "", ""
 	}
-	if !unicode.IsLetter(s.content[0]) {
+	if r, _ := utf8.DecodeRuneInString(s.content); !unicode.IsLetter(r) {
 		return "", ""
 	}
 	offset := 0
 	colons := 0
 	for _, r := range s.content {
-		if r == ' ' || r == ')' || r =='\n' {
+		if delimiter(r) {
 			break
 		}
 		if !unicode.IsLetter(r) &&
@@ -379,87 +455,63 @@ func (s *script) EatSymbols() (string, string) {
 		if r == ':' {
 			colons++
 		}	
-		offset++
+		offset += utf8.RuneLen(r)
 	}
 	result := s.content[:offset]
-	words := strings.Split(string(result), ":")
-	if len(words) != 2 {
+	first, second, found := strings.Cut(result, ":")
+	if !found || colons != 1 {
 		return "", ""
 	}
-	first  := words[0]
-	second := words[1]
 	if !s.IsSymbol(first) || !s.IsSymbol(second) {
 		//if len(letters) == 0 || unicode.IsDigit(letters[0]) {
 		return "", ""
 	}
-	s.column += offset
-	s.content = s.content[offset:]
-	return words[0], words[1]
+	s.skip(offset)
+	return first, second
 }
 
 func (s *script) IsSymbol(value string) bool {
-	runes := []rune(value)
-	if len(runes) == 0 {
+	value = s.normal(value)
+	if len(value) == 0 {
 		return false
 	}
-	if !unicode.IsLetter(runes[0]) {
+	if r, _ := utf8.DecodeRuneInString(value); !unicode.IsLetter(r) {
 		return false
 	}
-	for _,  r := range runes {
+	for _,  r := range value {
 		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
 			return false
 		}
 	}	
-	last := runes[len(runes)-1]
-	if  last == '-' {
+	if  value[len(value)-1] == '-' {
 		return false
 	}
 	return true	
 }
 
 func (s *script) EatKeyword() string {
-	if len(s.content) < 2 {
-		return ""
+	if s.policy() {
+		return s.identify(func(t *script) []string { return []string{t.EatKeyword()} })[0]
 	}
-	if s.content[0] != ':' {
+	k, _, reason := s.keyword()
+	if reason != 0 {
 		return ""
 	}
-	if !unicode.IsLetter(s.content[1]) {
-		return ""
-	}
-	offset := 2
-	content := s.content[offset:]
-	for _, r := range content {
-		if r == ' ' {
-			break
-		}
-		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
-			return ""
-		}					
-		offset++
-	}
-	s.column += offset
-	result := s.content[:offset]
-	s.content = s.content[offset:]
-	return string(result)
+	return s.skip(len(k.text))
 }
 
 func (s *script) EatInteger() string {
-	var digits []rune = s.integer.Scan(s.content)
+	var digits string = s.integer.Scan(s.content)
 	if len(digits) == 0 {
 		return ""
 	}
-	s.content = s.content[len(digits):]
-	s.column+= len(digits)
-	return string(digits)
+	return s.skip(len(digits))
 }
 
 func (s *script) EatFloat() string {
-	var digits []rune = s.float.Scan(s.content)
+	var digits string = s.float.Scan(s.content)
 	if len(digits) == 0 {
 		return ""
 	}
-	s.content = s.content[len(digits):]
-	s.column+= len(digits)
-	return string(digits)
+	return s.skip(len(digits))
 }
@@ -0,0 +1,105 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"unicode"
+	"unicode/utf8"
+)
+
+// The Skip methods let a parser recover after an error, each of them
+// returns the skipped text.
+
+// SkipToDelimiter skips up to the next delimiter, a delimiter other
+// than a space at the start is skipped alone.
+func (s *script) SkipToDelimiter() string {
+	if r, _ := utf8.DecodeRuneInString(s.content); delimiter(r) && !unicode.IsSpace(r) {
+		return s.skip(1)
+	}
+	for i, r := range s.content {
+		if delimiter(r) {
+			return s.skip(i)
+		}
+	}
+	return s.skip(len(s.content))
+}
+
+// SkipBalancedForm skips a list up to its matching parenthesis or a
+// string, raw string or heredoc up to its end, anything else is
+// skipped up to the next delimiter. Comments and strings inside a list
+// are skipped whole. An unbalanced form is skipped up to the end.
+func (s *script) SkipBalancedForm() string {
+	if len(s.content) == 0 {
+		return ""
+	}
+	if s.content[0] != '(' && s.content[0] != '"' && !raw(s.content) {
+		return s.SkipToDelimiter()
+	}
+	t := *s
+	depth := 0
+	for !t.Empty() {
+		t.EatSpaces()
+		switch {
+		case t.Empty():
+		case t.EatComment() != "":
+		case t.Eat("("):
+			depth++
+		case t.Eat(")"):
+			depth--
+		default:
+			if err := t.skipToken(); err != nil {
+				return s.pass(len(s.content))
+			}
+		}
+		if depth <= 0 {
+			return s.pass(len(s.content) - len(t.content))
+		}
+	}
+	return s.pass(len(s.content))
+}
+
+// skipToken skips the string, raw string, heredoc or atom content
+// starts with, with the scanners of the lexer.
+func (s *script) skipToken() error {
+	var err error
+	switch {
+	case s.BeginWith(`"""`):
+		_, err = s.EatHeredoc()
+	case raw(s.content):
+		_, err = s.EatRawString()
+	case s.BeginWith(`"`):
+		_, err = s.TryEatString()
+	default:
+		n := len(atom(s.content))
+		if n == 0 {
+			_, n = utf8.DecodeRuneInString(s.content)
+		}
+		s.skip(n)
+	}
+	return err
+}
+
+// SkipToNextTopLevel skips at least one rune and then up to the next
+// parenthesis found at the start of a line, which is where top-level
+// forms are expected to begin.
+func (s *script) SkipToNextTopLevel() string {
+	if len(s.content) == 0 {
+		return ""
+	}
+	_, size := utf8.DecodeRuneInString(s.content)
+	for i := size; i < len(s.content); i++ {
+		if s.content[i] == '(' && s.content[i-1] == '\n' {
+			return s.pass(i)
+		}
+	}
+	return s.pass(len(s.content))
+}
+
+// pass consumes the first n bytes of content, which may span lines.
+func (s *script) pass(n int) string {
+	result := s.content[:n]
+	s.Eat(result)
+	return result
+}
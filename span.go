@@ -0,0 +1,38 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"fmt"
+)
+
+// Span is the region of a script between two locations, the end
+// location is the first position after the region.
+type Span interface {
+	Start() Location
+	End() Location
+	String() string
+}
+
+func NewSpan(start, end Location) Span {
+	return span{start, end}
+}
+
+type span struct {
+	start Location
+	end   Location
+}
+
+func (s span) Start() Location {
+	return s.start
+}
+
+func (s span) End() Location {
+	return s.end
+}
+
+func (s span) String() string {
+	return fmt.Sprintf("%s-%d:%d", s.start, s.end.Line(), s.end.Column())
+}
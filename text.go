@@ -4,6 +4,9 @@
 
 package peruse
 
+// Text is a script being read. The Eat methods of tokens stop at a
+// delimiter: a space (tabs and \r included), a parenthesis, a double
+// quote or a semicolon, the runes that end the tokens of Lex.
 type Text interface {
 	Origin() string
 	Column() int
@@ -12,33 +15,56 @@ type Text interface {
 	Length() int
 	Empty() bool
 	Remain() string
+	Checkpoint() Checkpoint
+	Restore(Checkpoint)
 
 	BeginWith(string) bool
 	Eat(string) bool
 	EatFunctionName(string) bool
 	EatSpaces()
+	EatComment() string
+	SkipToDelimiter() string
+	SkipBalancedForm() string
+	SkipToNextTopLevel() string
 	EatString() (string, bool)
+	TryEatString() (string, error)
+	EatRawString() (Literal, error)
+	EatHeredoc() (Literal, error)
+	EatInterpolatedString() ([]Part, error)
 	
 	EatWord() string
+	TryEatWord() (string, error)
 	EatPrefixedWord(prefix string) (word, prefixed_word string)
+	TryEatPrefixedWord(prefix string) (word, prefixed_word string, err error)
 	// EatSuffixedWord(suffix) (string, string)
 	// EatAffixedWord(prefix, suffix) (string, string)
 	EatWords() (string, string)
+	TryEatWords() (string, string, error)
 	IsWord(string) bool
 	
 	EatSymbol() string
+	TryEatSymbol() (string, error)
 	EatPrefixedSymbol(prefix string) (symbol, prefixed_symbol string)
+	TryEatPrefixedSymbol(prefix string) (symbol, prefixed_symbol string, err error)
 	EatSymbols() (string, string)
+	TryEatSymbols() (string, string, error)
+	EatQualifiedSymbol(separators ...string) ([]Segment, error)
 	IsSymbol(string) bool
 
 	EatKeyword() string
-	//EatKey() string
-	//IsKeyword(string) bool
+	TryEatKeyword() (string, error)
+	EatKey() (Key, error)
+	IsKeyword(string) bool
 	
 	//EatKeysymbol(string) bool
 	//IsKeysymbol(string) bool
 	EatInteger() string
+	TryEatInteger() (string, error)
 	EatFloat() string
+	TryEatFloat() (string, error)
+	EatQuantity() (Quantity, error)
+	EatWith(Scanner) string
+	EatLexeme() (Kind, string)
 }
 
 
@@ -0,0 +1,290 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// Tree holds the top-level forms of a script together with the
+// content they were read from.
+type Tree interface {
+	Origin() string
+	Content() string
+	Forms() []Form
+}
+
+// Parse reads all forms of content, options are those of Script and
+// are kept by the tree for Reparse.
+func Parse(origin, content string, options ...Option) (Tree, error) {
+	text := Script(origin, content, options...)
+	r := reader{text: text, base: text.Length()}
+	forms, extents, err := r.readAll()
+	if err != nil {
+		return nil, err
+	}
+	return &tree{origin, content, forms, options, extents}, nil
+}
+
+type tree struct {
+	origin  string
+	content string
+	forms   []Form
+	options []Option
+	extents []extent
+}
+
+// extent is the offsets of the text read for a top-level form, it
+// starts before the reader conditionals that are read with it and
+// ends at location.
+type extent struct {
+	start    int
+	end      int
+	location Location
+}
+
+// extent returns the extent of the top-level form i.
+func (t *tree) extent(i int) extent {
+	if t.extents != nil {
+		return t.extents[i]
+	}
+	f := plain(t.forms[i])
+	return extent{f.start, f.end, f.span.End()}
+}
+
+func (t *tree) Origin() string {
+	return t.origin
+}
+
+func (t *tree) Content() string {
+	return t.content
+}
+
+func (t *tree) Forms() []Form {
+	return t.forms
+}
+
+// Rescan returns a text of the content of t with the options it was
+// parsed with, a tree not made by Parse has no options.
+func Rescan(t Tree) Text {
+	if t, ok := t.(*tree); ok {
+		return Script(t.origin, t.content, t.options...)
+	}
+	return Script(t.Origin(), t.Content())
+}
+
+// Edit replaces the text covered by span with replacement.
+type Edit interface {
+	Span() Span
+	Replacement() string
+}
+
+func NewEdit(span Span, replacement string) Edit {
+	return edit{span, replacement}
+}
+
+type edit struct {
+	span        Span
+	replacement string
+}
+
+func (e edit) Span() Span {
+	return e.span
+}
+
+func (e edit) Replacement() string {
+	return e.replacement
+}
+
+// Reparse applies edits in order, the span of each edit refers to the
+// content produced by the edits before it. Only the top-level forms
+// touched by an edit are read again, the others are kept and their
+// locations are shifted. The result is the same as parsing the edited
+// content from scratch.
+func Reparse(previous Tree, edits ...Edit) (Tree, error) {
+	t, ok := previous.(*tree)
+	if !ok {
+		content := previous.Content()
+		for _, e := range edits {
+			runes := []rune(content)
+			from, to, err := bounds(runes, e.Span())
+			if err != nil {
+				return nil, err
+			}
+			content = string(runes[:from]) + e.Replacement() + string(runes[to:])
+		}
+		return Parse(previous.Origin(), content)
+	}
+	for _, e := range edits {
+		var err error
+		if t, err = t.reparse(e); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (t *tree) reparse(e Edit) (*tree, error) {
+	runes := []rune(t.content)
+	from, to, err := bounds(runes, e.Span())
+	if err != nil {
+		return nil, err
+	}
+	replacement := []rune(e.Replacement())
+	content := string(runes[:from]) + string(replacement) + string(runes[to:])
+	delta := len(replacement) - (to - from)
+
+	// forms[:first] end before the edit and forms[last:] start after
+	// it, a form that only touches the edit is read again since the
+	// replacement may join it. Forms with datum labels, or read after a
+	// reader conditional, are read again rather than shifted.
+	first := 0
+	for first < len(t.forms) && t.extent(first).end < from {
+		first++
+	}
+	last := first
+	for last < len(t.forms) && t.extent(last).start <= to {
+		last++
+	}
+	offset, location := 0, NewLocation(t.origin, 1, 1)
+	if first > 0 {
+		previous := t.extent(first - 1)
+		offset, location = previous.end, previous.location
+	}
+	forms := append([]Form{}, t.forms[:first]...)
+	extents := []extent{}
+	for i := range forms {
+		extents = append(extents, t.extent(i))
+	}
+	text := resume(Script(t.origin, content, t.options...), offset, location)
+	r := reader{text: text, base: text.Length() + offset}
+	for {
+		space(text)
+		if text.Empty() {
+			break
+		}
+		offset := r.offset()
+		for last < len(t.forms) && t.extent(last).start+delta < offset {
+			last++
+		}
+		if last < len(t.forms) && t.shiftable(last, offset-delta) {
+			old := t.forms[last].Span().Start()
+			s := shifter{
+				offset: delta,
+				line:   text.Line() - old.Line(),
+				column: text.Column() - old.Column(),
+				first:  old.Line(),
+			}
+			for i, f := range t.forms[last:] {
+				forms = append(forms, s.shift(f.(form)))
+				e := t.extent(last + i)
+				extents = append(extents, extent{e.start + delta, e.end + delta, s.location(e.location)})
+			}
+			break
+		}
+		f, err := r.read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		forms = append(forms, f)
+		extents = append(extents, extent{offset, r.offset(), text.Location()})
+	}
+	return &tree{t.origin, content, forms, t.options, extents}, nil
+}
+
+// shiftable tells whether forms[i:] can be shifted to start at offset
+// of the previous content, forms[i] must be read from there.
+func (t *tree) shiftable(i, offset int) bool {
+	e := t.extent(i)
+	return e.start == offset && e.start == plain(t.forms[i]).start && !labelled(t.forms[i:]...)
+}
+
+// resume moves text to offset, which is found at location.
+func resume(text Text, offset int, location Location) Text {
+	s := text.(*script)
+	for ; offset > 0; offset-- {
+		_, size := utf8.DecodeRuneInString(s.content)
+		s.content = s.content[size:]
+		s.length--
+	}
+	s.line = location.Line()
+	s.column = location.Column()
+	return s
+}
+
+// shifter moves forms that follow an edit to their new place, only
+// locations on the first line of the moved forms change their column.
+type shifter struct {
+	offset int
+	line   int
+	column int
+	first  int
+}
+
+func (s shifter) shift(f form) form {
+	f.start += s.offset
+	f.end += s.offset
+	f.span = NewSpan(s.location(f.span.Start()), s.location(f.span.End()))
+	if f.forms != nil {
+		forms := make([]Form, len(f.forms))
+		for i, child := range f.forms {
+			forms[i] = s.shift(child.(form))
+		}
+		f.forms = forms
+	}
+	return f
+}
+
+func (s shifter) location(l Location) Location {
+	column := l.Column()
+	if l.Line() == s.first {
+		column += s.column
+	}
+	return NewLocation(l.Origin(), l.Line()+s.line, column)
+}
+
+// bounds converts span into offsets of content.
+func bounds(content []rune, span Span) (int, int, error) {
+	from, err := position(content, span.Start())
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := position(content, span.End())
+	if err != nil {
+		return 0, 0, err
+	}
+	if to < from {
+		return 0, 0, fmt.Errorf("%s: invalid edit range", span)
+	}
+	return from, to, nil
+}
+
+func position(content []rune, l Location) (int, error) {
+	line, column := 1, 1
+	for i, r := range content {
+		if line == l.Line() && column == l.Column() {
+			return i, nil
+		}
+		if r == '\n' {
+			if line == l.Line() {
+				break
+			}
+			line++
+			column = 1
+			continue
+		}
+		column++
+	}
+	if line == l.Line() && column == l.Column() {
+		return len(content), nil
+	}
+	return 0, fmt.Errorf("%s: location is outside of the content", l)
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 Abdulrahman Abdulhamid. All rights reserved.
+// Use of this source code is governed by Apache-2.0 
+// license that can be found in the LICENSE file.
+
+package peruse
+
+import(
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The TryEat methods eat like their Eat counterpart, on failure the
+// content is left untouched and a Failure tells why.
+
+func (s *script) TryEatString() (string, error) {
+	if value, ok := s.EatString(); ok {
+		return value, nil
+	}
+	if err := s.starts("string", func(r rune) bool { return r == '"' }); err != nil {
+		return "", err
+	}
+	return "", s.fail(0, Unterminated, "string")
+}
+
+func (s *script) TryEatWord() (string, error) {
+	if word := s.EatWord(); word != "" {
+		return word, nil
+	}
+	if err := s.starts("word", unicode.IsLetter); err != nil {
+		return "", err
+	}
+	return "", s.name("word", 0, len(s.until()), false)
+}
+
+func (s *script) TryEatPrefixedWord(prefix string) (string, string, error) {
+	if prefix == "" {
+		word, err := s.TryEatWord()
+		return word, word, err
+	}
+	if word, prefixed := s.EatPrefixedWord(prefix); prefixed != "" {
+		return word, prefixed, nil
+	}
+	return "", "", s.prefixed("word", prefix, false)
+}
+
+func (s *script) TryEatWords() (string, string, error) {
+	if first, second := s.EatWords(); first != "" {
+		return first, second, nil
+	}
+	return "", "", s.qualified("qualified word", false)
+}
+
+func (s *script) TryEatSymbol() (string, error) {
+	if symbol := s.EatSymbol(); symbol != "" {
+		return symbol, nil
+	}
+	if err := s.starts("symbol", unicode.IsLetter); err != nil {
+		return "", err
+	}
+	return "", s.name("symbol", 0, len(s.until()), true)
+}
+
+func (s *script) TryEatPrefixedSymbol(prefix string) (string, string, error) {
+	if prefix == "" {
+		symbol, err := s.TryEatSymbol()
+		return symbol, symbol, err
+	}
+	if symbol, prefixed := s.EatPrefixedSymbol(prefix); prefixed != "" {
+		return symbol, prefixed, nil
+	}
+	return "", "", s.prefixed("symbol", prefix, true)
+}
+
+func (s *script) TryEatSymbols() (string, string, error) {
+	if first, second := s.EatSymbols(); first != "" {
+		return first, second, nil
+	}
+	return "", "", s.qualified("qualified symbol", true)
+}
+
+func (s *script) TryEatKeyword() (string, error) {
+	if keyword := s.EatKeyword(); keyword != "" {
+		return keyword, nil
+	}
+	_, at, reason := s.keyword()
+	if reason == 0 {
+		at, reason = 0, NotApplicable
+	}
+	return "", s.fail(at, reason, "keyword")
+}
+
+func (s *script) TryEatInteger() (string, error) {
+	if integer := s.EatInteger(); integer != "" {
+		return integer, nil
+	}
+	if len(s.content) == 0 {
+		return "", s.fail(0, EndOfText, "integer")
+	}
+	token, offset := s.until(), 0
+	if token != "" && (token[0] == '+' || token[0] == '-') {
+		offset = 1
+	}
+	if r, _ := utf8.DecodeRuneInString(token[offset:]); !unicode.IsDigit(r) {
+		return "", s.fail(0, NotApplicable, "integer")
+	}
+	for i, r := range token[offset:] {
+		if !unicode.IsDigit(r) {
+			return "", s.fail(offset+i, InvalidChar, "integer")
+		}
+	}
+	return "", s.fail(0, NotApplicable, "integer")
+}
+
+func (s *script) TryEatFloat() (string, error) {
+	if float := s.EatFloat(); float != "" {
+		return float, nil
+	}
+	if len(s.content) == 0 {
+		return "", s.fail(0, EndOfText, "float")
+	}
+	token, offset := s.until(), 0
+	if token != "" && (token[0] == '+' || token[0] == '-') {
+		offset = 1
+	}
+	if r, _ := utf8.DecodeRuneInString(token[offset:]); r != '.' && !unicode.IsDigit(r) {
+		return "", s.fail(0, NotApplicable, "float")
+	}
+	dots := 0
+	for i, r := range token[offset:] {
+		if r == '.' {
+			if dots++; dots > 1 {
+				return "", s.fail(offset+i, InvalidChar, "float")
+			}
+			continue
+		}
+		if !unicode.IsDigit(r) {
+			return "", s.fail(offset+i, InvalidChar, "float")
+		}
+	}
+	return "", s.fail(0, NotApplicable, "float")
+}
+
+// until returns the content up to the first delimiter.
+func (s *script) until() string {
+	for i, r := range s.content {
+		if delimiter(r) {
+			return s.content[:i]
+		}
+	}
+	return s.content
+}
+
+// fail reports reason at the byte offset n of content, which must be
+// on the current line.
+func (s *script) fail(n int, reason Reason, lexeme string) Failure {
+	r := rune(0)
+	if n < len(s.content) {
+		r, _ = utf8.DecodeRuneInString(s.content[n:])
+	}
+	return NewFailure(s.at(n), reason, lexeme, r)
+}
+
+// at returns the location of the byte offset n of content, which must
+// be on the current line.
+func (s *script) at(n int) Location {
+	return NewLocation(s.origin, s.line, s.column+utf8.RuneCountInString(s.content[:n]))
+}
+
+// starts checks that content starts with a rune accepted by valid.
+func (s *script) starts(lexeme string, valid func(rune) bool) Failure {
+	if len(s.content) == 0 {
+		return s.fail(0, EndOfText, lexeme)
+	}
+	if r, _ := utf8.DecodeRuneInString(s.content); !valid(r) {
+		return s.fail(0, NotApplicable, lexeme)
+	}
+	return nil
+}
+
+// name checks content[from:to] to be a word, or a symbol when hyphen
+// is allowed.
+func (s *script) name(lexeme string, from, to int, hyphen bool) Failure {
+	if from == to {
+		if to == len(s.content) {
+			return s.fail(to, EndOfText, lexeme)
+		}
+		return s.fail(to, InvalidChar, lexeme)
+	}
+	if at, reason := checkName(s.content[from:to], hyphen); reason != 0 {
+		return s.fail(from+at, reason, lexeme)
+	}
+	return s.fail(from, NotApplicable, lexeme)
+}
+
+// checkName returns the offset of the first problem that keeps value
+// from being a word, or a symbol when hyphen is allowed, and why.
+func checkName(value string, hyphen bool) (int, Reason) {
+	if r, _ := utf8.DecodeRuneInString(value); !unicode.IsLetter(r) {
+		return 0, InvalidChar
+	}
+	for i, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !(hyphen && r == '-') {
+			return i, InvalidChar
+		}
+	}
+	if hyphen && value[len(value)-1] == '-' {
+		return len(value)-1, TrailingHyphen
+	}
+	return 0, 0
+}
+
+func (s *script) prefixed(lexeme, prefix string, hyphen bool) Failure {
+	if len(s.content) == 0 {
+		return s.fail(0, EndOfText, lexeme)
+	}
+	if !strings.HasPrefix(s.content, prefix) {
+		return s.fail(0, NotApplicable, lexeme)
+	}
+	return s.name(lexeme, len(prefix), max(len(s.until()), len(prefix)), hyphen)
+}
+
+// qualified diagnoses two names separated by a colon.
+func (s *script) qualified(lexeme string, hyphen bool) Failure {
+	if err := s.starts(lexeme, unicode.IsLetter); err != nil {
+		return err
+	}
+	token := s.until()
+	colon := -1
+	for i, r := range token {
+		if r == ':' {
+			if colon >= 0 {
+				return s.fail(i, ExtraSeparator, lexeme)
+			}
+			colon = i
+			continue
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !(hyphen && r == '-') {
+			return s.fail(i, InvalidChar, lexeme)
+		}
+	}
+	if colon < 0 {
+		return s.fail(len(token), MissingSeparator, lexeme)
+	}
+	if colon == len(token)-1 {
+		return s.fail(colon+1, EmptyPart, lexeme)
+	}
+	if hyphen && token[colon-1] == '-' {
+		return s.fail(colon-1, TrailingHyphen, lexeme)
+	}
+	return s.name(lexeme, colon+1, len(token), hyphen)
+}